@@ -27,6 +27,8 @@ func main() {
 		runGenerator(command)
 	case "builder":
 		runBuilder()
+	case "schema":
+		runSchema()
 	case "init":
 		initTemplates()
 	case "help", "-h", "--help":
@@ -50,6 +52,14 @@ func runGenerator(command string) {
 	templateDir := fs.String("template-dir", "", "Custom template directory")
 	configFile := fs.String("config", "", "Config file path")
 	migrations := fs.String("migrations", "./db/migrations", "Migrations path")
+	schemaFile := fs.String("schema-file", "", "Schema snapshot (pg_dump --schema-only or schema.sql) to read instead of the migrations")
+	dialect := fs.String("dialect", "", "SQL dialect of the migrations: postgres, mysql or sqlite (default from config)")
+	migrationFormat := fs.String("migration-format", "", "Migration layout: golang-migrate, goose, dbmate or atlas (default from config)")
+	nullableStyle := fs.String("nullable-style", "", "Go types of nullable columns: sqlnull, pointer or generic (default from config)")
+	modulePath := fs.String("module-path", "", "Import path of the project module (default from config, else go.mod)")
+	localPrefix := fs.String("local-prefix", "", "Import path prefix of the project's packages, grouped last in imports (default from config, else the module path)")
+	force := fs.Bool("force", false, "Overwrite existing files")
+	skipExisting := fs.Bool("skip-existing", false, "Only create missing files, keeping existing ones")
 
 	// Output directories
 	entityOut := fs.String("entity-out", "./modules/%s/entity", "Entity output directory")
@@ -69,6 +79,35 @@ func runGenerator(command string) {
 	if err != nil {
 		log.Fatalf("Config error: %v", err)
 	}
+	if *dialect != "" {
+		if err := cfg.SetDialect(*dialect); err != nil {
+			log.Fatalf("Config error: %v", err)
+		}
+	}
+	if *migrationFormat != "" {
+		if err := cfg.SetMigrationFormat(*migrationFormat); err != nil {
+			log.Fatalf("Config error: %v", err)
+		}
+	}
+	if *nullableStyle != "" {
+		if err := cfg.SetNullableStyle(*nullableStyle); err != nil {
+			log.Fatalf("Config error: %v", err)
+		}
+	}
+	if *schemaFile != "" {
+		cfg.SchemaFile = *schemaFile
+	}
+	if *modulePath != "" {
+		cfg.ModulePath = *modulePath
+	}
+	if *localPrefix != "" {
+		cfg.LocalPrefix = *localPrefix
+	}
+	if mode := overwriteMode(*force, *skipExisting); mode != "" {
+		if err := cfg.SetOverwrite(mode); err != nil {
+			log.Fatalf("Config error: %v", err)
+		}
+	}
 
 	// Validate inputs
 	if command != "module" && *table == "" {
@@ -109,7 +148,7 @@ func runGenerator(command string) {
 	case "module":
 		// Use table name directly as entity name
 		entityName := strings.ToLower(*table)
-		if err := gen.GenerateModule(*schema, entityName, *version, parts, *moduleOut); err != nil {
+		if err := gen.GenerateModule(*schema, entityName, *version, *migrations, parts, *moduleOut); err != nil {
 			log.Fatalf("Generate module error: %v", err)
 		}
 	case "version":
@@ -125,6 +164,16 @@ func runBuilder() {
 	tables := fs.String("tables", "", "Comma-separated table names (e.g., users,roles,permissions)") // CHANGED: tables -> tables
 	version := fs.String("version", "v1", "API version")
 	newModule := fs.Bool("new-module", false, "Generate complete new module")
+	templateDir := fs.String("template-dir", "", "Custom template directory")
+	configFile := fs.String("config", "", "Config file path")
+	migrations := fs.String("migrations", "./db/migrations", "Path to migrations, used to detect views")
+	schemaFile := fs.String("schema-file", "", "Schema snapshot (pg_dump --schema-only or schema.sql) to read instead of the migrations")
+	dialect := fs.String("dialect", "", "SQL dialect of the migrations: postgres, mysql or sqlite (default from config)")
+	migrationFormat := fs.String("migration-format", "", "Migration layout: golang-migrate, goose, dbmate or atlas (default from config)")
+	modulePath := fs.String("module-path", "", "Import path of the project module (default from config, else go.mod)")
+	localPrefix := fs.String("local-prefix", "", "Import path prefix of the project's packages, grouped last in imports (default from config, else the module path)")
+	force := fs.Bool("force", false, "Overwrite existing files")
+	skipExisting := fs.Bool("skip-existing", false, "Only create missing files, keeping existing ones")
 	dryRun := fs.Bool("dry-run", false, "Show what will be generated without writing files")
 
 	_ = fs.Parse(os.Args[2:])
@@ -141,10 +190,34 @@ func runBuilder() {
 	}
 
 	// Load configuration
-	cfg, err := config.Load("", "")
+	cfg, err := config.Load(*configFile, *templateDir)
 	if err != nil {
 		log.Fatalf("Config error: %v", err)
 	}
+	if *dialect != "" {
+		if err := cfg.SetDialect(*dialect); err != nil {
+			log.Fatalf("Config error: %v", err)
+		}
+	}
+	if *migrationFormat != "" {
+		if err := cfg.SetMigrationFormat(*migrationFormat); err != nil {
+			log.Fatalf("Config error: %v", err)
+		}
+	}
+	if *schemaFile != "" {
+		cfg.SchemaFile = *schemaFile
+	}
+	if *modulePath != "" {
+		cfg.ModulePath = *modulePath
+	}
+	if *localPrefix != "" {
+		cfg.LocalPrefix = *localPrefix
+	}
+	if mode := overwriteMode(*force, *skipExisting); mode != "" {
+		if err := cfg.SetOverwrite(mode); err != nil {
+			log.Fatalf("Config error: %v", err)
+		}
+	}
 
 	// Run builder generator
 	gen := generator.NewGenerator(cfg)
@@ -155,11 +228,81 @@ func runBuilder() {
 		return
 	}
 
-	if err := gen.GenerateBuilder(*module, *version, tableList, *newModule); err != nil {
+	if err := gen.GenerateBuilder(*module, *version, *migrations, tableList, *newModule); err != nil {
 		log.Fatalf("Builder generation error: %v", err)
 	}
 }
 
+func runSchema() {
+	if len(os.Args) < 3 || (os.Args[2] != "show" && os.Args[2] != "export") {
+		log.Fatal("Usage: starter-cli schema show --schema=<schema> --table=<table> | starter-cli schema export --format=json|yaml")
+	}
+	action := os.Args[2]
+	fs := flag.NewFlagSet("schema "+action, flag.ExitOnError)
+
+	schema := fs.String("schema", "public", "Schema name")
+	table := fs.String("table", "", "Table name (required for show)")
+	format := fs.String("format", "json", "Export format: json or yaml")
+	output := fs.String("output", "", "Export file (default: stdout)")
+	configFile := fs.String("config", "", "Config file path")
+	migrations := fs.String("migrations", "./db/migrations", "Migrations path")
+	schemaFile := fs.String("schema-file", "", "Schema snapshot (pg_dump --schema-only or schema.sql) to read instead of the migrations")
+	dialect := fs.String("dialect", "", "SQL dialect of the migrations: postgres, mysql or sqlite (default from config)")
+	migrationFormat := fs.String("migration-format", "", "Migration layout: golang-migrate, goose, dbmate or atlas (default from config)")
+	nullableStyle := fs.String("nullable-style", "", "Go types of nullable columns: sqlnull, pointer or generic (default from config)")
+
+	_ = fs.Parse(os.Args[3:])
+
+	// Load configuration
+	cfg, err := config.Load(*configFile, "")
+	if err != nil {
+		log.Fatalf("Config error: %v", err)
+	}
+	if *dialect != "" {
+		if err := cfg.SetDialect(*dialect); err != nil {
+			log.Fatalf("Config error: %v", err)
+		}
+	}
+	if *migrationFormat != "" {
+		if err := cfg.SetMigrationFormat(*migrationFormat); err != nil {
+			log.Fatalf("Config error: %v", err)
+		}
+	}
+	if *nullableStyle != "" {
+		if err := cfg.SetNullableStyle(*nullableStyle); err != nil {
+			log.Fatalf("Config error: %v", err)
+		}
+	}
+	if *schemaFile != "" {
+		cfg.SchemaFile = *schemaFile
+	}
+
+	gen := generator.NewGenerator(cfg)
+
+	switch action {
+	case "show":
+		if *table == "" {
+			log.Fatal("Missing required flag: --table")
+		}
+		if err := gen.ShowTable(*schema, *table, *migrations, os.Stdout); err != nil {
+			log.Fatalf("Schema show error: %v", err)
+		}
+	case "export":
+		out := os.Stdout
+		if *output != "" {
+			f, err := os.Create(*output)
+			if err != nil {
+				log.Fatalf("Schema export error: %v", err)
+			}
+			defer f.Close()
+			out = f
+		}
+		if err := gen.ExportSchema(*migrations, *format, out); err != nil {
+			log.Fatalf("Schema export error: %v", err)
+		}
+	}
+}
+
 func parseModuleParts(partsStr string) []types.ModulePart {
 	var parts []types.ModulePart
 
@@ -202,6 +345,7 @@ Commands:
   resource  Generate resource (DTO) only from database table
   module    Generate module components (handler, service, repository)
   builder   Generate builder and routes for modules
+  schema    Inspect (schema show) or export (schema export) the parsed schema
   help      See usage information
   version   Show version information
 
@@ -212,7 +356,17 @@ Common Flags:
   --version        API version (default: v1)
   --parts          Module parts to generate: handler,service,repository (default: all)
   --template-dir   Custom template directory (overrides embedded templates)
+  --config         Config file (config.yaml) with the dialect, module path, naming and type options
   --migrations     Path to database migrations (default: ./db/migrations)
+  --dialect        SQL dialect of the migrations: postgres, mysql or sqlite (default: postgres, or dialect in config)
+  --migration-format
+                   Migration layout: golang-migrate, goose, dbmate or atlas (default: golang-migrate, or migration_format in config)
+  --schema-file    Schema snapshot (pg_dump --schema-only or schema.sql) to read instead of the migrations
+  --nullable-style Go types of nullable columns: sqlnull, pointer or generic (default: sqlnull, or nullable_style in config)
+  --module-path    Import path of the project module (default: module_path in config, else the module in go.mod)
+  --local-prefix   Import path prefix of the project's packages, grouped last in imports (default: the module path)
+  --force          Overwrite files that already exist (also for init)
+  --skip-existing  Only create missing files and keep existing ones (also for init)
 
 Template Customization:
   # Initialize template directory for customization
@@ -247,6 +401,12 @@ Examples:
   # Preview changes without writing files
   starter-cli builder --module=auth --tables=organizations --dry-run
 
+  # Show how a table was parsed and which Go types its columns map to
+  starter-cli schema show --schema=auth --table=users
+
+  # Export every parsed table for other tooling
+  starter-cli schema export --format=yaml --output=schema.yaml
+
 Module Parts Syntax:
   --parts=handler                    # All handler actions
   --parts=handler.creator            # Only creator handler
@@ -261,7 +421,31 @@ Output:
   • Routes:     ./app/{module/schema}_routes.go`)
 }
 
+// overwriteMode returns the overwrite mode selected by the --force and
+// --skip-existing flags, or "" to keep the configured one
+func overwriteMode(force, skipExisting bool) string {
+	switch {
+	case force && skipExisting:
+		log.Fatal("Flags --force and --skip-existing cannot be combined")
+	case force:
+		return string(types.OverwriteForce)
+	case skipExisting:
+		return string(types.OverwriteSkip)
+	}
+	return ""
+}
+
 func initTemplates() {
+	fs := flag.NewFlagSet("init", flag.ExitOnError)
+	force := fs.Bool("force", false, "Overwrite existing templates")
+	skipExisting := fs.Bool("skip-existing", false, "Only create missing templates, keeping existing ones")
+	_ = fs.Parse(os.Args[2:])
+
+	mode, err := types.ParseOverwriteMode(overwriteMode(*force, *skipExisting))
+	if err != nil {
+		log.Fatalf("Config error: %v", err)
+	}
+
 	fmt.Println("🚀 Initializing template directory...")
 
 	templateDir := "./templates"
@@ -272,8 +456,7 @@ func initTemplates() {
 	}
 
 	// Copy all embedded templates to filesystem
-	err := generator.CopyEmbeddedTemplates(templateDir)
-	if err != nil {
+	if err := generator.CopyEmbeddedTemplates(templateDir, mode); err != nil {
 		log.Fatalf("Failed to copy templates: %v", err)
 	}
 
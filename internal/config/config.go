@@ -1,21 +1,71 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v2"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
 )
 
 // Config holds all template paths and generator configuration
 type Config struct {
-	TemplatePaths TemplatePaths `yaml:"template_paths"`
+	// Dialect is the SQL dialect of the migrations: postgres (default), mysql or sqlite
+	Dialect types.Dialect `yaml:"dialect"`
+	// MigrationFormat is the migration tool layout: golang-migrate (default), goose, dbmate or atlas
+	MigrationFormat types.MigrationFormat `yaml:"migration_format"`
+	// SchemaFile is a schema snapshot (pg_dump --schema-only or schema.sql) read instead of the migrations
+	SchemaFile string `yaml:"schema_file"`
+	// NullableStyle selects the Go types of nullable columns: sqlnull (default), pointer or generic
+	NullableStyle types.NullableStyle `yaml:"nullable_style"`
+	// Overwrite selects what happens to generated files that already exist: refuse (default), force or skip
+	Overwrite types.OverwriteMode `yaml:"overwrite"`
+	// Inflections adds irregular plurals and uncountable nouns to the built-in English rules
+	Inflections Inflections `yaml:"inflections"`
+	// Initialisms are words written in upper case in identifiers, besides the golint ones (ID, URL, ...)
+	Initialisms []string `yaml:"initialisms"`
+	// ModulePath is the import path of the project module the code is generated
+	// into, read from its go.mod when not configured
+	ModulePath string `yaml:"module_path"`
+	// LocalPrefix is the import path prefix of the project's own packages, which
+	// the generated files import as a separate group after third-party packages.
+	// It defaults to the module path.
+	LocalPrefix string `yaml:"local_prefix"`
+	// TypeMappings maps SQL type patterns to Go types, ahead of the built-in entries of the dialect
+	TypeMappings  map[string]TypeMapping `yaml:"type_mappings"`
+	TemplatePaths TemplatePaths          `yaml:"template_paths"`
+}
+
+// TypeMapping defines the Go types of columns whose SQL type matches a pattern.
+// Patterns are case-insensitive and * matches any run of characters; a pattern
+// without * also matches the type without its arguments or schema, so "numeric"
+// matches NUMERIC(12,2). Conversion expressions refer to the entity field as {field},
+// imports to the project module as {module}.
+type TypeMapping struct {
+	GoType             string   `yaml:"go_type"`              // entity type of NOT NULL columns
+	NullableGoType     string   `yaml:"nullable_go_type"`     // sqlnull style type of nullable columns, defaults to sql.Null[go_type]
+	ResourceType       string   `yaml:"resource_type"`        // defaults to string
+	RequestType        string   `yaml:"request_type"`         // defaults to resource_type
+	FromEntity         string   `yaml:"from_entity"`          // entity to resource conversion, defaults to {field}
+	NullableFromEntity string   `yaml:"nullable_from_entity"` // conversion of nullable columns, defaults to from_entity
+	Imports            []string `yaml:"imports"`              // packages of the entity types, besides database/sql and time
+	ResourceImports    []string `yaml:"resource_imports"`     // packages of the resource and request types and conversions
+}
+
+// Inflections customizes how table and entity names are pluralized and singularized
+type Inflections struct {
+	Irregular   map[string]string `yaml:"irregular"`   // singular: plural, e.g. cactus: cacti
+	Uncountable []string          `yaml:"uncountable"` // nouns without a plural, e.g. equipment
 }
 
 // TemplatePaths defines all customizable template file paths
 type TemplatePaths struct {
 	// Entity templates
 	Entity string `yaml:"entity"`
+	Enum   string `yaml:"entity_enum"`
 
 	// Resource templates
 	Resource      string `yaml:"resource"`
@@ -59,12 +109,90 @@ func Load(configPath, templateDir string) (*Config, error) {
 		}
 	}
 
+	if err := cfg.SetDialect(string(cfg.Dialect)); err != nil {
+		return nil, err
+	}
+	if err := cfg.SetMigrationFormat(string(cfg.MigrationFormat)); err != nil {
+		return nil, err
+	}
+	if err := cfg.SetNullableStyle(string(cfg.NullableStyle)); err != nil {
+		return nil, err
+	}
+	if err := cfg.SetOverwrite(string(cfg.Overwrite)); err != nil {
+		return nil, err
+	}
+	for pattern, mapping := range cfg.TypeMappings {
+		if mapping.GoType == "" {
+			return nil, fmt.Errorf("type_mappings: %q has no go_type", pattern)
+		}
+	}
+
+	if cfg.ModulePath == "" {
+		cfg.ModulePath = readModulePath("go.mod")
+	}
+
 	// Set default template paths if not configured
 	setDefaultTemplatePaths(cfg, templateDir)
 
 	return cfg, nil
 }
 
+// SetDialect validates and sets the SQL dialect, e.g. from the --dialect flag
+func (c *Config) SetDialect(name string) error {
+	dialect, err := types.ParseDialect(name)
+	if err != nil {
+		return err
+	}
+	c.Dialect = dialect
+	return nil
+}
+
+// SetMigrationFormat validates and sets the migration format, e.g. from the --migration-format flag
+func (c *Config) SetMigrationFormat(name string) error {
+	format, err := types.ParseMigrationFormat(name)
+	if err != nil {
+		return err
+	}
+	c.MigrationFormat = format
+	return nil
+}
+
+// SetNullableStyle validates and sets the nullable style, e.g. from the --nullable-style flag
+func (c *Config) SetNullableStyle(name string) error {
+	style, err := types.ParseNullableStyle(name)
+	if err != nil {
+		return err
+	}
+	c.NullableStyle = style
+	return nil
+}
+
+// SetOverwrite validates and sets the overwrite mode, e.g. from the --force and --skip-existing flags
+func (c *Config) SetOverwrite(name string) error {
+	mode, err := types.ParseOverwriteMode(name)
+	if err != nil {
+		return err
+	}
+	c.Overwrite = mode
+	return nil
+}
+
+// readModulePath returns the module path declared in a go.mod file, or
+// gin-starter, the module of the starter project, when there is none
+func readModulePath(goMod string) string {
+	data, err := os.ReadFile(goMod)
+	if err != nil {
+		return "gin-starter"
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line, _, _ = strings.Cut(line, "//")
+		if fields := strings.Fields(line); len(fields) == 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], "\"`")
+		}
+	}
+	return "gin-starter"
+}
+
 func setDefaultTemplatePaths(cfg *Config, templateDir string) {
 	baseDir := templateDir
 	if baseDir == "" {
@@ -77,6 +205,9 @@ func setDefaultTemplatePaths(cfg *Config, templateDir string) {
 	if paths.Entity == "" {
 		paths.Entity = filepath.Join(baseDir, "entity/entity.tmpl")
 	}
+	if paths.Enum == "" {
+		paths.Enum = filepath.Join(baseDir, "entity/enum.tmpl")
+	}
 
 	// Resource templates
 	if paths.Resource == "" {
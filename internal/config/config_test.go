@@ -0,0 +1,88 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+func TestReadModulePath(t *testing.T) {
+	tests := []struct {
+		name  string
+		goMod string
+		want  string
+	}{
+		{"plain", "module github.com/acme/shop\n\ngo 1.22\n", "github.com/acme/shop"},
+		{"quoted", "module \"github.com/acme/shop\"\n", "github.com/acme/shop"},
+		{"comments", "// the shop API\nmodule github.com/acme/shop // deprecated: use v2\n", "github.com/acme/shop"},
+		{"no module line", "go 1.22\n", "gin-starter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "go.mod")
+			if err := os.WriteFile(path, []byte(tt.goMod), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if got := readModulePath(path); got != tt.want {
+				t.Errorf("readModulePath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := readModulePath(filepath.Join(t.TempDir(), "go.mod")); got != "gin-starter" {
+		t.Errorf("readModulePath() without go.mod = %q, want %q", got, "gin-starter")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "starter.yaml")
+	if err := os.WriteFile(path, []byte("dialect: mysql\nnullable_style: pointer\nmodule_path: github.com/acme/shop\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path, "custom")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Dialect != types.DialectMySQL || cfg.NullableStyle != types.NullablePointer {
+		t.Errorf("Load() dialect, nullable style = %q, %q", cfg.Dialect, cfg.NullableStyle)
+	}
+	if cfg.MigrationFormat != types.FormatGolangMigrate || cfg.Overwrite != types.OverwriteRefuse {
+		t.Errorf("Load() defaults = %q, %q", cfg.MigrationFormat, cfg.Overwrite)
+	}
+	if cfg.ModulePath != "github.com/acme/shop" {
+		t.Errorf("Load() module path = %q", cfg.ModulePath)
+	}
+	if want := filepath.Join("custom", "entity/entity.tmpl"); cfg.TemplatePaths.Entity != want {
+		t.Errorf("Load() entity template = %q, want %q", cfg.TemplatePaths.Entity, want)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   string
+	}{
+		{"unknown dialect", "dialect: oracle\n", "oracle"},
+		{"mapping without go_type", "type_mappings:\n  numeric:\n    resource_type: string\n", `"numeric" has no go_type`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "starter.yaml")
+			if err := os.WriteFile(path, []byte(tt.config), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := Load(path, "")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -1,29 +1,41 @@
 package generator
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/rifqiakrm/starter-cli/internal/inflection"
+)
 
 // GenerateBuilder generates builder files and routes
-func (g *Generator) GenerateBuilder(module, version string, tables []string, newModule bool) error {
+func (g *Generator) GenerateBuilder(module, version, migrationsPath string, tables []string, newModule bool) error {
 	fmt.Printf("🚀 Generating builder for module '%s' with tables: %v\n", module, tables)
 
+	views, keys := g.inspectTables(module, migrationsPath, tables)
+
 	if newModule {
-		return g.generateNewModule(module, version, tables)
+		return g.generateNewModule(module, version, tables, views, keys)
 	} else {
-		return g.generateIncremental(module, version, tables)
+		return g.generateIncremental(module, version, tables, views, keys)
 	}
 }
 
 // generateNewModule creates complete new module
-func (g *Generator) generateNewModule(module, version string, tables []string) error {
+func (g *Generator) generateNewModule(module, version string, tables []string, views map[string]bool, keys map[string]string) error {
 	fmt.Printf("🆕 Creating new module '%s'\n", module)
 
 	// Generate complete builder
-	if err := g.generateCompleteBuilder(module, version, tables); err != nil {
+	builder, err := g.generateCompleteBuilder(module, version, tables, views)
+	if err != nil {
 		return err
 	}
 
 	// Generate complete routes
-	if err := g.generateCompleteRoutes(module, version, tables); err != nil {
+	routes, err := g.generateCompleteRoutes(module, version, tables, views, keys)
+	if err != nil {
+		return err
+	}
+
+	if err := g.writeFiles([]outputFile{builder, routes}); err != nil {
 		return err
 	}
 
@@ -36,18 +48,53 @@ func (g *Generator) generateNewModule(module, version string, tables []string) e
 }
 
 // generateIncremental adds to existing module
-func (g *Generator) generateIncremental(module, version string, tables []string) error {
+func (g *Generator) generateIncremental(module, version string, tables []string, views map[string]bool, keys map[string]string) error {
 	fmt.Printf("📈 Adding to existing module '%s'\n", module)
 
 	// Update builder incrementally
-	if err := g.updateBuilderIncremental(module, version, tables); err != nil {
+	if err := g.updateBuilderIncremental(module, version, tables, views); err != nil {
 		return fmt.Errorf("update builder error: %v", err)
 	}
 
 	// Update routes incrementally
-	if err := g.updateRoutesIncremental(module, version, tables); err != nil {
+	if err := g.updateRoutesIncremental(module, version, tables, views, keys); err != nil {
 		return fmt.Errorf("update routes error: %v", err)
 	}
 
 	return nil
 }
+
+// inspectTables returns the requested tables that are views in the module
+// schema, and the route parameters of each table's primary key. Tables are
+// matched by name or by their plural form. When the schema cannot be read
+// every table is treated as writable and keyed by id.
+func (g *Generator) inspectTables(module, migrationsPath string, tables []string) (map[string]bool, map[string]string) {
+	views := make(map[string]bool)
+	keys := make(map[string]string)
+	for _, table := range tables {
+		keys[table] = "/:id"
+	}
+
+	cat, err := g.loadCatalog(migrationsPath)
+	if err != nil {
+		fmt.Printf("⚠️  Could not read the schema, treating all tables as writable: %v\n", err)
+		return views, keys
+	}
+	g.printWarnings(cat)
+
+	for _, table := range tables {
+		tbl := cat.FindTable(module, table)
+		if tbl == nil {
+			tbl = cat.FindTable(module, inflection.Plural(table))
+		}
+		if tbl == nil {
+			continue
+		}
+		if tbl.IsView {
+			fmt.Printf("👁️  %s is a view, wiring finder only\n", table)
+			views[table] = true
+		}
+		keys[table] = g.primaryKey(tbl).Path()
+	}
+	return views, keys
+}
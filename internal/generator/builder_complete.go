@@ -2,52 +2,42 @@ package generator
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 	"text/template"
 
+	"github.com/rifqiakrm/starter-cli/internal/inflection"
+	"github.com/rifqiakrm/starter-cli/internal/naming"
 	"github.com/rifqiakrm/starter-cli/internal/types"
 )
 
 // generateCompleteBuilder generates a complete new builder file
-func (g *Generator) generateCompleteBuilder(module, version string, tables []string) error {
+func (g *Generator) generateCompleteBuilder(module, version string, tables []string, views map[string]bool) (outputFile, error) {
 	fmt.Printf("🏗️  Generating complete builder for %s\n", module)
 
 	// Prepare builder configuration
-	config := g.buildBuilderConfig(module, version, tables)
+	config := g.buildBuilderConfig(module, version, tables, views)
 
 	// Generate builder code
 	builderCode, err := g.generateBuilderCode(config)
 	if err != nil {
-		return fmt.Errorf("builder template error: %v", err)
+		return outputFile{}, fmt.Errorf("builder template error: %v", err)
 	}
 
-	// Create module directory
-	moduleDir := filepath.Join("modules", module)
-	if err := os.MkdirAll(moduleDir, 0755); err != nil {
-		return fmt.Errorf("mkdir error: %v", err)
-	}
-
-	// Write builder file
-	builderFile := filepath.Join(moduleDir, "builder.go")
-	if err := os.WriteFile(builderFile, []byte(builderCode), 0644); err != nil {
-		return fmt.Errorf("write builder error: %v", err)
-	}
-
-	fmt.Printf("✅ Generated builder: %s\n", builderFile)
-	return nil
+	builderFile := filepath.Join("modules", module, "builder.go")
+	return outputFile{path: builderFile, code: builderCode, label: "builder"}, nil
 }
 
 // buildBuilderConfig creates dynamic configuration for any module
-func (g *Generator) buildBuilderConfig(module, version string, tables []string) types.BuilderConfig {
+func (g *Generator) buildBuilderConfig(module, version string, tables []string, views map[string]bool) types.BuilderConfig {
 	tableConfigs := make([]types.TableConfig, 0, len(tables))
 
 	for _, table := range tables {
 		tableConfigs = append(tableConfigs, types.TableConfig{
 			Name:        table,
-			DisplayName: toPascalCase(table),
+			DisplayName: naming.Pascal(table),
 			Module:      module,
+			IsView:      views[table],
 		})
 	}
 
@@ -56,8 +46,9 @@ func (g *Generator) buildBuilderConfig(module, version string, tables []string)
 		Version:       version,
 		Tables:        tableConfigs,
 		RoutePrefix:   fmt.Sprintf("/%s/%s", module, version),
-		ImportPath:    fmt.Sprintf("gin-starter/modules/%s/%s", module, version),
-		HandlerPrefix: toPascalCase(module),
+		ModulePath:    g.config.ModulePath,
+		ImportPath:    fmt.Sprintf("%s/modules/%s/%s", g.config.ModulePath, module, version),
+		HandlerPrefix: naming.Pascal(module),
 		HasAuth:       module == "auth",
 		HasCron:       module == "auth", // Only auth has cron in your example
 		CustomImports: g.getCustomImports(module, version),
@@ -69,7 +60,7 @@ func (g *Generator) getCustomImports(module, version string) []string {
 	imports := []string{}
 
 	if module == "auth" {
-		imports = append(imports, `cronjobHandler "gin-starter/modules/auth/v1/cronjob/handler"`)
+		imports = append(imports, fmt.Sprintf(`cronjobHandler "%s/modules/%s/%s/cronjob/handler"`, g.config.ModulePath, module, version))
 	}
 
 	return imports
@@ -90,9 +81,11 @@ func (g *Generator) generateBuilderCode(config types.BuilderConfig) (string, err
 
 	// Create template with functions
 	funcMap := template.FuncMap{
-		"ToCamel":  toCamelCase,
-		"ToPascal": toPascalCase,
+		"ToCamel":  naming.Camel,
+		"ToPascal": naming.Pascal,
 		"ToLower":  strings.ToLower,
+		"Plural":   inflection.Plural,
+		"Singular": inflection.Singular,
 	}
 
 	tmpl, err := template.New("builder").Funcs(funcMap).Parse(tmplContent)
@@ -105,5 +98,9 @@ func (g *Generator) generateBuilderCode(config types.BuilderConfig) (string, err
 		return "", fmt.Errorf("execute builder template error: %v", err)
 	}
 
-	return buf.String(), nil
+	code, err := g.formatGo(buf.String())
+	if err != nil {
+		return "", fmt.Errorf("builder template: %v", err)
+	}
+	return code, nil
 }
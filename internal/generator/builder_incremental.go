@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"os"
 	"strings"
+
+	"github.com/rifqiakrm/starter-cli/internal/naming"
 )
 
 // updateBuilderIncremental adds new tables to existing builder
-func (g *Generator) updateBuilderIncremental(module, version string, newTables []string) error {
+func (g *Generator) updateBuilderIncremental(module, version string, newTables []string, views map[string]bool) error {
 	fmt.Printf("📈 Updating existing builder for %s with new tables: %v\n", module, newTables)
 
 	// Analyze existing builder
@@ -26,13 +28,13 @@ func (g *Generator) updateBuilderIncremental(module, version string, newTables [
 	fmt.Printf("🔍 Adding %d new tables: %v\n", len(tablesToAdd), tablesToAdd)
 
 	// Generate code for new tables only
-	newCode, err := g.generateIncrementalBuilderCode(module, version, tablesToAdd)
+	newCode, err := g.generateIncrementalBuilderCode(module, version, tablesToAdd, views)
 	if err != nil {
 		return fmt.Errorf("generate incremental code error: %v", err)
 	}
 
 	// Update the builder file
-	if err := g.updateBuilderFile(analysis, newCode, tablesToAdd); err != nil {
+	if err := g.updateBuilderFile(analysis, newCode, tablesToAdd, views); err != nil {
 		return fmt.Errorf("update builder file error: %v", err)
 	}
 
@@ -52,8 +54,8 @@ func (g *Generator) filterNewTables(requested, existing []string) []string {
 }
 
 // generateIncrementalBuilderCode generates code only for new tables
-func (g *Generator) generateIncrementalBuilderCode(module, version string, tables []string) (string, error) {
-	config := g.buildBuilderConfig(module, version, tables)
+func (g *Generator) generateIncrementalBuilderCode(module, version string, tables []string, views map[string]bool) (string, error) {
+	config := g.buildBuilderConfig(module, version, tables, views)
 
 	var result strings.Builder
 	result.WriteString("\n")
@@ -62,6 +64,15 @@ func (g *Generator) generateIncrementalBuilderCode(module, version string, table
 		result.WriteString(fmt.Sprintf("\t// %s Repository\n", entity.DisplayName))
 		result.WriteString(fmt.Sprintf("\t%sFinderRepo := repository.New%sFinderRepository(db, cache)\n",
 			entity.Name, entity.DisplayName))
+
+		// views are read-only and only get a finder
+		if entity.IsView {
+			result.WriteString(fmt.Sprintf("\n\t// %s Service\n", entity.DisplayName))
+			result.WriteString(fmt.Sprintf("\t%sFinderSvc := service.New%sFinder(cfg, %sFinderRepo, cloudStorage)\n\n",
+				entity.Name, entity.DisplayName, entity.Name))
+			continue
+		}
+
 		result.WriteString(fmt.Sprintf("\t%sCreatorRepo := repository.New%sCreatorRepository(db, cache)\n",
 			entity.Name, entity.DisplayName))
 		result.WriteString(fmt.Sprintf("\t%sUpdaterRepo := repository.New%sUpdaterRepository(db, cache)\n",
@@ -84,7 +95,7 @@ func (g *Generator) generateIncrementalBuilderCode(module, version string, table
 }
 
 // updateBuilderFile updates the existing builder file with new tables
-func (g *Generator) updateBuilderFile(analysis *BuilderAnalysis, newCode string, tables []string) error {
+func (g *Generator) updateBuilderFile(analysis *BuilderAnalysis, newCode string, tables []string, views map[string]bool) error {
 	lines := strings.Split(analysis.Content, "\n")
 
 	// Step 1: Find safe insertion point for entity wiring (after last complete entity)
@@ -100,9 +111,13 @@ func (g *Generator) updateBuilderFile(analysis *BuilderAnalysis, newCode string,
 	updatedLines = append(updatedLines, lines[entityInsertLine:]...)
 
 	// Step 3: Update handler constructor call
-	finalContent := g.updateHandlerConstructor(strings.Join(updatedLines, "\n"), tables)
+	finalContent := g.updateHandlerConstructor(strings.Join(updatedLines, "\n"), tables, views)
 
 	// Write updated content
+	finalContent, err := g.formatGo(finalContent)
+	if err != nil {
+		return fmt.Errorf("updated builder file %s: %v", analysis.FilePath, err)
+	}
 	return os.WriteFile(analysis.FilePath, []byte(finalContent), 0644)
 }
 
@@ -128,7 +143,7 @@ func (g *Generator) findEntityWiringInsertionLine(lines []string) int {
 }
 
 // updateHandlerConstructor adds new tables to handler constructor parameters
-func (g *Generator) updateHandlerConstructor(content string, tables []string) string {
+func (g *Generator) updateHandlerConstructor(content string, tables []string, views map[string]bool) string {
 	lines := strings.Split(content, "\n")
 
 	for i, line := range lines {
@@ -139,7 +154,11 @@ func (g *Generator) updateHandlerConstructor(content string, tables []string) st
 					// Insert new tables before cloudStorage/cache
 					newParams := ""
 					for _, entity := range tables {
-						displayName := toPascalCase(entity)
+						displayName := naming.Pascal(entity)
+						if views[entity] {
+							newParams += fmt.Sprintf("\t\t// %s\n\t\t%sFinderSvc,\n", displayName, entity)
+							continue
+						}
 						newParams += fmt.Sprintf("\t\t// %s\n\t\t%sCreatorSvc, %sFinderSvc, %sUpdaterSvc, %sDeleterSvc,\n",
 							displayName, entity, entity, entity, entity)
 					}
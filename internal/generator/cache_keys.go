@@ -3,11 +3,13 @@ package generator
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 )
 
-// generateCacheKeysForEntity generates Redis cache key constants for a specific entity
-func (g *Generator) generateCacheKeysForEntity(schema, entity string) error {
+// generateCacheKeysForEntity generates Redis cache key constants for a specific entity:
+// FindByID plus one key per unique FindBy lookup
+func (g *Generator) generateCacheKeysForEntity(schema, entity string, data TemplateData) error {
 	fmt.Printf("🔑 Generating cache keys for %s.%s\n", schema, entity)
 
 	cacheFilePath := "common/cache/redis.go"
@@ -25,19 +27,21 @@ func (g *Generator) generateCacheKeysForEntity(schema, entity string) error {
 
 	contentStr := string(content)
 
-	// Check if cache keys already exist for this entity
-	if g.cacheKeysExist(contentStr, schema, entity) {
+	// Generate the cache key constants that do not exist yet
+	newCacheKeys := g.generateCacheKeyConstants(contentStr, schema, entity, data)
+	if newCacheKeys == "" {
 		fmt.Printf("✅ Cache keys already exist for %s.%s\n", schema, entity)
 		return nil
 	}
 
-	// Generate new cache key constants
-	newCacheKeys := g.generateCacheKeyConstants(schema, entity)
-
 	// Find insertion point and update content
 	updatedContent := g.insertCacheKeys(contentStr, newCacheKeys)
 
 	// Write updated content
+	updatedContent, err = g.formatGo(updatedContent)
+	if err != nil {
+		return fmt.Errorf("updated cache file %s: %v", cacheFilePath, err)
+	}
 	if err := os.WriteFile(cacheFilePath, []byte(updatedContent), 0644); err != nil {
 		return fmt.Errorf("write cache file error: %v", err)
 	}
@@ -46,31 +50,48 @@ func (g *Generator) generateCacheKeysForEntity(schema, entity string) error {
 	return nil
 }
 
-// cacheKeysExist checks if cache keys already exist for this entity
-func (g *Generator) cacheKeysExist(content, schema, entity string) bool {
-	displayName := toPascalCase(entity)
-	searchPattern := fmt.Sprintf("%sFindByID", displayName)
-	return strings.Contains(content, searchPattern)
+// cacheKeyPlaceholders returns the number of %v placeholders of a declared
+// cache key constant, and whether the constant is declared
+func (g *Generator) cacheKeyPlaceholders(content, constName string) (int, bool) {
+	line := regexp.MustCompile(`(?m)^\s*` + constName + `\s*=.*$`).FindString(content)
+	if line == "" {
+		return 0, false
+	}
+	return strings.Count(line, "%v"), true
+}
+
+// cacheKey describes a cache key constant of an entity
+type cacheKey struct {
+	suffix string // constant name suffix, e.g. "FindByEmail"
+	action string // redis key action, e.g. "find-by-email"
+	args   int    // number of lookup values in the key
+	desc   string
 }
 
-// generateCacheKeyConstants generates the cache key constant definitions for an entity
-func (g *Generator) generateCacheKeyConstants(schema, entity string) string {
+// generateCacheKeyConstants generates the missing cache key constant definitions for an entity
+func (g *Generator) generateCacheKeyConstants(content, schema, entity string, data TemplateData) string {
 	var result strings.Builder
 
-	displayName := toPascalCase(entity)
-
-	// Generate cache keys for common repository patterns
-	cacheKeys := []struct {
-		suffix string
-		desc   string
-	}{
-		{"FindByID", fmt.Sprintf("find %s by id", entity)},
-		{"FindByName", fmt.Sprintf("find %s by name", entity)},
+	// Generate cache keys for the cached repository lookups
+	cacheKeys := []cacheKey{
+		{"FindByID", data.Key.KeySuffix(), len(data.Key.keyed()), fmt.Sprintf("find %s by id", entity)},
+	}
+	for _, f := range data.CachedFinders {
+		desc := fmt.Sprintf("find %s by %s", entity, f.Description())
+		cacheKeys = append(cacheKeys, cacheKey{"FindBy" + f.Name, f.KeySuffix(), len(f.keyed()), desc})
 	}
 
 	for _, key := range cacheKeys {
-		constName := fmt.Sprintf("%s%s", displayName, key.suffix)
-		redisKey := g.generateRedisKey(schema, entity, strings.ToLower(key.suffix))
+		constName := data.EntityUpper + key.suffix
+		if placeholders, ok := g.cacheKeyPlaceholders(content, constName); ok {
+			if placeholders != key.args {
+				// keys generated with another shape, which the generator does not rewrite
+				fmt.Printf("⚠️  %s has %d placeholder(s) but the repositories pass %d values; update it to prefix + \":%s:%s\"\n",
+					constName, placeholders, key.args, schema, g.generateRedisKey(schema, entity, key.action, key.args))
+			}
+			continue
+		}
+		redisKey := g.generateRedisKey(schema, entity, key.action, key.args)
 
 		result.WriteString(fmt.Sprintf("\t// %s is a redis key for %s.\n", constName, key.desc))
 		result.WriteString(fmt.Sprintf("\t%s = prefix + \":%s:%s\"\n", constName, schema, redisKey))
@@ -79,11 +100,9 @@ func (g *Generator) generateCacheKeyConstants(schema, entity string) string {
 	return result.String()
 }
 
-// generateRedisKey generates the Redis key pattern
-func (g *Generator) generateRedisKey(schema, entity, action string) string {
-	// Convert action to kebab-case if needed
-	action = strings.ReplaceAll(action, "by", "-by-")
-	return fmt.Sprintf("%s:%s:%%v", entity, action)
+// generateRedisKey generates the Redis key pattern with one placeholder per lookup value
+func (g *Generator) generateRedisKey(schema, entity, action string, args int) string {
+	return fmt.Sprintf("%s:%s%s", entity, action, strings.Repeat(":%v", args))
 }
 
 // insertCacheKeys inserts new cache keys before the last closing parenthesis
@@ -7,6 +7,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
 )
 
 //go:embed templates/**/*
@@ -34,8 +36,11 @@ func (g *Generator) loadTemplate(templatePath string) (string, error) {
 	return string(content), nil
 }
 
-// CopyEmbeddedTemplates copies all embedded templates to the filesystem
-func CopyEmbeddedTemplates(targetDir string) error {
+// CopyEmbeddedTemplates copies all embedded templates to the filesystem.
+// Existing templates are handled according to the overwrite mode.
+func CopyEmbeddedTemplates(targetDir string, mode types.OverwriteMode) error {
+	var files []outputFile
+
 	// Walk through the embedded template filesystem
 	err := fs.WalkDir(templateFS, "templates", func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
@@ -49,25 +54,20 @@ func CopyEmbeddedTemplates(targetDir string) error {
 
 		targetPath := filepath.Join(targetDir, strings.TrimPrefix(path, "templates/"))
 
-		if d.IsDir() {
-			// Create directory
-			return os.MkdirAll(targetPath, 0755)
-		} else {
+		if !d.IsDir() {
 			// Copy file
 			content, err := templateFS.ReadFile(path)
 			if err != nil {
 				return fmt.Errorf("read embedded file %s: %v", path, err)
 			}
-
-			if err := os.WriteFile(targetPath, content, 0644); err != nil {
-				return fmt.Errorf("write template file %s: %v", targetPath, err)
-			}
-
-			fmt.Printf("📄 Created: %s\n", targetPath)
+			files = append(files, outputFile{path: targetPath, code: string(content), label: "template"})
 		}
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	return writeOutputFiles(files, mode)
 }
@@ -2,59 +2,53 @@ package generator
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 	"text/template"
 
-	"github.com/rifqiakrm/starter-cli/internal/parser"
+	"github.com/rifqiakrm/starter-cli/internal/inflection"
+	"github.com/rifqiakrm/starter-cli/internal/naming"
+	"github.com/rifqiakrm/starter-cli/internal/types"
 )
 
-// GenerateEntity generates entity code from SQL migrations
 // GenerateEntity generates entity code from SQL migrations
 func (g *Generator) GenerateEntity(schema, table, migrationsPath, outputDir string) error {
-	fmt.Printf("🔍 Finding migration for %s.%s...\n", schema, table)
-
-	// Find SQL migration file
-	sqlFile, err := parser.FindMigration(migrationsPath, schema, table)
+	tbl, err := g.loadTable(schema, table, migrationsPath)
 	if err != nil {
-		return fmt.Errorf("migration not found: %v", err)
+		return err
 	}
-
-	// Parse SQL to get table structure
-	fmt.Printf("📝 Parsing SQL file: %s\n", sqlFile)
-	tbl, err := parser.ParseSQL(sqlFile)
+	files, err := g.generateEntityFiles(schema, tbl, outputDir)
 	if err != nil {
-		return fmt.Errorf("parse error: %v", err)
+		return err
 	}
+	return g.writeFiles(files)
+}
 
+// generateEntityFiles renders the entity of a table and the enum types it uses
+func (g *Generator) generateEntityFiles(schema string, tbl *types.Table, outputDir string) ([]outputFile, error) {
 	// Format output directory (supports %s placeholder for schema)
 	formattedOutputDir := strings.Replace(outputDir, "%s", schema, 1)
 
 	// Load and execute template
 	fmt.Printf("⚡ Generating entity for %s...\n", tbl.Name)
-	code, err := g.generateFromTemplate("entity", tbl, g.config.TemplatePaths.Entity)
+	code, err := g.generateFromTemplate("entity", TableData{Table: tbl, ModulePath: g.config.ModulePath}, g.config.TemplatePaths.Entity)
 	if err != nil {
-		return fmt.Errorf("template error: %v", err)
-	}
-
-	// Create output directory
-	if err := os.MkdirAll(formattedOutputDir, 0755); err != nil {
-		return fmt.Errorf("mkdir error: %v", err)
+		return nil, fmt.Errorf("template error: %v", err)
 	}
 
-	// Write generated file
 	filename := fmt.Sprintf("%s.entity.go", tbl.NameLower)
-	outputPath := filepath.Join(formattedOutputDir, filename)
-	if err := os.WriteFile(outputPath, []byte(code), 0644); err != nil {
-		return fmt.Errorf("write error: %v", err)
-	}
+	files := []outputFile{{path: filepath.Join(formattedOutputDir, filename), code: code, label: "entity"}}
 
-	fmt.Printf("✅ Generated entity: %s\n", outputPath)
-	return nil
+	// Generate the enum types used by the entity
+	enums, err := g.generateEnums(tbl, formattedOutputDir)
+	if err != nil {
+		return nil, err
+	}
+	return append(files, enums...), nil
 }
 
-// generateFromTemplate is a helper to load and execute templates
+// generateFromTemplate is a helper to load and execute templates of Go files,
+// returning the formatted code
 func (g *Generator) generateFromTemplate(templateName string, data interface{}, templatePath string) (string, error) {
 	// Try to load custom template first, fallback to embedded defaults
 	tmplContent, err := g.loadTemplate(templatePath)
@@ -64,11 +58,20 @@ func (g *Generator) generateFromTemplate(templateName string, data interface{},
 
 	// Create template with helper functions
 	funcMap := template.FuncMap{
-		"ToCamel":      toCamelCase,
-		"ToPascalCase": toPascalCase,
-		"ToLowerCamel": toLowerCamelCase,
-		"GoType":       goType,
-		"IsAuditable":  isAuditable,
+		"ToCamel":         naming.Camel,
+		"ToPascalCase":    naming.Pascal,
+		"ToLowerCamel":    naming.Camel,
+		"GoType":          g.mapper().goType,
+		"EntityImports":   g.mapper().entityImports,
+		"TableName":       g.tableName,
+		"GormTag":         gormTag,
+		"DocLines":        docLines,
+		"IsAuditable":     isAuditable,
+		"IncludeInCreate": includeInCreate,
+		"IncludeInUpdate": includeInUpdate,
+		"Plural":          inflection.Plural,
+		"Singular":        inflection.Singular,
+		"LocalName":       localName,
 	}
 
 	tmpl, err := template.New(templateName).Funcs(funcMap).Parse(tmplContent)
@@ -81,5 +84,9 @@ func (g *Generator) generateFromTemplate(templateName string, data interface{},
 		return "", err
 	}
 
-	return buf.String(), nil
+	code, err := g.formatGo(buf.String())
+	if err != nil {
+		return "", fmt.Errorf("template %s: %v", templateName, err)
+	}
+	return code, nil
 }
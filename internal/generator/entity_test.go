@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rifqiakrm/starter-cli/internal/config"
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+func TestEntityImports(t *testing.T) {
+	tests := []struct {
+		name    string
+		tbl     *types.Table
+		imports []string // expected import lines, none when the block is left out
+	}{
+		{
+			name: "view without imports",
+			tbl: &types.Table{Name: "user_names", NameUpper: "UserName", NameLower: "userName", IsView: true,
+				Columns: []types.Column{{Name: "name", Type: "TEXT"}}},
+		},
+		{
+			name: "view with imports",
+			tbl: &types.Table{Name: "user_logins", NameUpper: "UserLogin", NameLower: "userLogin", IsView: true,
+				Columns: []types.Column{{Name: "logged_in_at", Type: "TIMESTAMPTZ"}}},
+			imports: []string{`"time"`},
+		},
+		{
+			name: "table",
+			tbl: &types.Table{Name: "tags", NameUpper: "Tag", NameLower: "tag",
+				Columns: []types.Column{{Name: "id", Type: "BIGINT", PrimaryKey: true}, {Name: "label", Type: "TEXT"}}},
+			imports: []string{`commonEntity "example.com/app/entity"`},
+		},
+	}
+
+	g := NewGenerator(&config.Config{Dialect: types.DialectPostgres, ModulePath: "example.com/app",
+		TemplatePaths: config.TemplatePaths{Entity: "templates/entity/entity.tmpl", Enum: "templates/entity/enum.tmpl"}})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := g.generateEntityFiles("public", tt.tbl, t.TempDir())
+			if err != nil {
+				t.Fatalf("generateEntityFiles() error = %v", err)
+			}
+			code := files[0].code
+			if len(tt.imports) == 0 {
+				if strings.Contains(code, "import") {
+					t.Errorf("entity has an import block:\n%s", code)
+				}
+				return
+			}
+			for _, imp := range tt.imports {
+				if !strings.Contains(code, imp) {
+					t.Errorf("entity does not import %s:\n%s", imp, code)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/rifqiakrm/starter-cli/internal/naming"
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// EnumData holds data passed to the enum template
+type EnumData struct {
+	ModulePath string // import path of the project module, e.g. github.com/acme/billing
+	Schema     string
+	SQLName    string
+	Name       string
+	Values     []EnumValue
+}
+
+// EnumValue is a single enum constant
+type EnumValue struct {
+	Const string
+	Value string
+}
+
+// generateEnums renders one Go file per enum type used by the table's columns
+func (g *Generator) generateEnums(tbl *types.Table, outputDir string) ([]outputFile, error) {
+	var files []outputFile
+	seen := make(map[*types.Enum]bool)
+	for _, col := range tbl.Columns {
+		if col.Enum == nil || seen[col.Enum] {
+			continue
+		}
+		seen[col.Enum] = true
+
+		data := newEnumData(col.Enum)
+		data.ModulePath = g.config.ModulePath
+		code, err := g.generateFromTemplate("enum", data, g.config.TemplatePaths.Enum)
+		if err != nil {
+			return nil, fmt.Errorf("enum template error: %v", err)
+		}
+
+		outputPath := filepath.Join(outputDir, fmt.Sprintf("%s.enum.go", strings.ToLower(col.Enum.Name)))
+		files = append(files, outputFile{path: outputPath, code: code, label: "enum"})
+	}
+	return files, nil
+}
+
+// newEnumData builds template data with unique Go constant names for every value
+func newEnumData(enum *types.Enum) EnumData {
+	data := EnumData{
+		Schema:  enum.Schema,
+		SQLName: enum.Name,
+		Name:    enumTypeName(enum),
+	}
+
+	used := make(map[string]bool)
+	for i, value := range enum.Values {
+		constName := data.Name + enumValueIdentifier(value)
+		if used[constName] {
+			constName = fmt.Sprintf("%s%d", constName, i)
+		}
+		used[constName] = true
+		data.Values = append(data.Values, EnumValue{Const: constName, Value: value})
+	}
+	return data
+}
+
+// enumTypeName returns the Go type name of an enum, e.g. user_status -> UserStatus
+func enumTypeName(enum *types.Enum) string {
+	return naming.Pascal(strings.ToLower(enum.Name))
+}
+
+// enumValueIdentifier turns an enum value into an identifier suffix, e.g. "in-review" -> InReview
+// and "2fa" -> X2fa
+func enumValueIdentifier(value string) string {
+	words := strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	ident := naming.Pascal(strings.Join(words, "_"))
+	if ident == "" {
+		return "Empty"
+	}
+	return ident
+}
+
+// enumOneOf renders the values of an enum as a gin "oneof" rule, or reports
+// false when a value holds a quote, comma or "|", which the rule cannot express
+func enumOneOf(enum *types.Enum) (string, bool) {
+	values := make([]string, len(enum.Values))
+	for i, v := range enum.Values {
+		if strings.ContainsAny(v, "',|") {
+			return "", false
+		}
+		if v == "" || strings.ContainsAny(v, " \t") {
+			v = "'" + v + "'"
+		}
+		values[i] = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(v)
+	}
+	return "oneof=" + strings.Join(values, " "), true
+}
@@ -0,0 +1,116 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rifqiakrm/starter-cli/internal/config"
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+func TestNewEnumData(t *testing.T) {
+	enum := &types.Enum{Schema: "public", Name: "ORDER_STATUS", Values: []string{
+		"pending", "in-review", "In Review", "2fa", "", "shipped!",
+	}}
+
+	data := newEnumData(enum)
+	if data.Name != "OrderStatus" || data.SQLName != "ORDER_STATUS" || data.Schema != "public" {
+		t.Errorf("newEnumData() = %s %s.%s, want OrderStatus public.ORDER_STATUS", data.Name, data.Schema, data.SQLName)
+	}
+	want := []EnumValue{
+		{Const: "OrderStatusPending", Value: "pending"},
+		{Const: "OrderStatusInReview", Value: "in-review"},
+		// values making the same identifier are told apart by their position
+		{Const: "OrderStatusInReview2", Value: "In Review"},
+		{Const: "OrderStatusX2fa", Value: "2fa"},
+		{Const: "OrderStatusEmpty", Value: ""},
+		{Const: "OrderStatusShipped", Value: "shipped!"},
+	}
+	if !reflect.DeepEqual(data.Values, want) {
+		t.Errorf("values =\n%+v\nwant\n%+v", data.Values, want)
+	}
+}
+
+func TestGenerateEnums(t *testing.T) {
+	status := &types.Enum{Name: "user_status", Values: []string{"active", "on hold"}}
+	tbl := &types.Table{Name: "users", Columns: []types.Column{
+		{Name: "id", Type: "UUID", PrimaryKey: true},
+		{Name: "status", Type: "user_status", Enum: status},
+		// a type used twice is generated once
+		{Name: "previous_status", Type: "user_status", Enum: status, Nullable: true},
+	}}
+
+	g := NewGenerator(&config.Config{Dialect: types.DialectPostgres, TemplatePaths: config.TemplatePaths{Enum: "templates/entity/enum.tmpl"}})
+	files, err := g.generateEnums(tbl, "entity")
+	if err != nil {
+		t.Fatalf("generateEnums() error = %v", err)
+	}
+	if len(files) != 1 || files[0].path != "entity/user_status.enum.go" {
+		t.Fatalf("generateEnums() files = %+v, want entity/user_status.enum.go", files)
+	}
+
+	for _, want := range []string{
+		"type UserStatus string",
+		`UserStatusActive UserStatus = "active"`,
+		`UserStatusOnHold UserStatus = "on hold"`,
+		"case UserStatusActive, UserStatusOnHold:",
+		"func ParseUserStatus(s string) (UserStatus, error)",
+	} {
+		if !strings.Contains(strings.Join(strings.Fields(files[0].code), " "), want) {
+			t.Errorf("enum file does not contain %q:\n%s", want, files[0].code)
+		}
+	}
+}
+
+func TestEnumBindingTag(t *testing.T) {
+	tests := []struct {
+		name   string
+		col    types.Column
+		create bool
+		want   string
+	}{
+		{
+			name:   "required on create",
+			col:    types.Column{Name: "status", Enum: &types.Enum{Values: []string{"active", "inactive"}}},
+			create: true,
+			want:   `binding:"required,oneof=active inactive"`,
+		},
+		{
+			name: "optional on update",
+			col:  types.Column{Name: "status", Enum: &types.Enum{Values: []string{"active", "inactive"}}},
+			want: `binding:"omitempty,oneof=active inactive"`,
+		},
+		{
+			name:   "values with spaces and double quotes",
+			col:    types.Column{Name: "kind", Nullable: true, Enum: &types.Enum{Values: []string{"on hold", `say "hi"`}}},
+			create: true,
+			want:   `binding:"omitempty,oneof='on hold' 'say \"hi\"'"`,
+		},
+		{
+			name:   "value with a single quote",
+			col:    types.Column{Name: "kind", Enum: &types.Enum{Values: []string{"on hold", "it's"}}},
+			create: true,
+			want:   `binding:"required"`,
+		},
+		{
+			name: "value with a comma",
+			col:  types.Column{Name: "kind", Length: 20, Enum: &types.Enum{Values: []string{"a,b", "c"}}},
+			want: `binding:"omitempty,max=20"`,
+		},
+		{
+			name:   "enum without values",
+			col:    types.Column{Name: "kind", Enum: &types.Enum{}},
+			create: true,
+			want:   `binding:"required"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bindingTag(tt.col, tt.create); got != tt.want {
+				t.Errorf("bindingTag() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
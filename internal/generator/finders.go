@@ -0,0 +1,486 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rifqiakrm/starter-cli/internal/naming"
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// scopeColumn is the tenant column finder queries are filtered by, when the table has it
+const scopeColumn = "org_unit_id"
+
+// Finder is a FindBy<Name> lookup generated from a unique constraint or an index.
+// Unique lookups return a single cached record, other indexes return a list.
+type Finder struct {
+	Name         string // method suffix, e.g. "Email" or "OrgUnitIDAndSlug"
+	Unique       bool
+	Columns      []FinderColumn
+	tenant       *FinderColumn // the tenant column scoping queries, nil when the table has none
+	tenantKeyed  bool          // whether cache keys end with the tenant, read back from entities
+	dialect      types.Dialect // quotes the column names of the query conditions
+	entityImport string        // package of the enum parameter types
+}
+
+// FinderColumn is a column a Finder looks up
+type FinderColumn struct {
+	Name        string // SQL column name
+	Param       string // method parameter name, "orgUnitID" for the tenant column
+	Type        string // method parameter type
+	Value       string // selector reading the lookup value from an entity, e.g. ".Email.String"
+	pointer     bool   // whether the selected field is a pointer, dereferenced in cache keys
+	parser      paramParser
+	parsable    bool     // whether Type can be parsed from a string
+	enum        bool     // whether Type is an enum of the entity package
+	typeImports []string // packages of a configured Type
+}
+
+// paramParser parses a route parameter into a Go type
+type paramParser struct {
+	call string // call returning the value and an error, with {src} for the string
+	pkg  string // package of the call
+}
+
+// paramParsers are the parameter types a route parameter can be parsed into,
+// keyed by Go type. Strings are used as is.
+var paramParsers = map[string]paramParser{
+	"string":    {},
+	"uuid.UUID": {"uuid.Parse({src})", "github.com/google/uuid"},
+	"ulid.ULID": {"ulid.Parse({src})", "github.com/oklog/ulid/v2"},
+	"int64":     {"strconv.ParseInt({src}, 10, 64)", "strconv"},
+	"uint64":    {"strconv.ParseUint({src}, 10, 64)", "strconv"},
+	"bool":      {"strconv.ParseBool({src})", "strconv"},
+	"float64":   {"strconv.ParseFloat({src}, 64)", "strconv"},
+}
+
+// Parse returns the expression parsing the string src into the parameter type,
+// returning the value and an error, e.g. `uuid.Parse(c.Param("id"))`. It returns
+// "" when the parameter is a plain string and src can be used as is.
+func (c FinderColumn) Parse(src string) string {
+	return strings.ReplaceAll(c.parser.call, "{src}", src)
+}
+
+// Lookups returns the columns passed as parameters, leaving out the tenant column
+func (f Finder) Lookups() []FinderColumn {
+	var cols []FinderColumn
+	for _, col := range f.Columns {
+		if col.Name != scopeColumn {
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
+
+// Imports returns the packages Parse needs for the lookup parameters
+func (f Finder) Imports() []string {
+	var imports []string
+	for _, col := range f.Lookups() {
+		imports = append(imports, col.parser.pkg)
+		if col.enum {
+			imports = append(imports, f.entityImport)
+		}
+	}
+	return uniqueImports(imports)
+}
+
+// TypeImports returns the packages of the lookup parameter types, leaving out
+// uuid when the lookup is scoped to the tenant, as every layer then already
+// imports it for the orgUnitID parameter
+func (f Finder) TypeImports() []string {
+	var imports []string
+	for _, col := range f.Lookups() {
+		for _, pkg := range col.typeImports {
+			if f.tenant == nil || pkg != "github.com/google/uuid" {
+				imports = append(imports, pkg)
+			}
+		}
+		if col.enum {
+			imports = append(imports, f.entityImport)
+		}
+	}
+	return uniqueImports(imports)
+}
+
+// parseError reports the first lookup parameter that cannot be parsed from a
+// route parameter, which the handlers would pass on with the wrong type
+func (f Finder) parseError() error {
+	for _, col := range f.Lookups() {
+		if !col.parsable {
+			var supported []string
+			for typ := range paramParsers {
+				supported = append(supported, typ)
+			}
+			sort.Strings(supported)
+			return fmt.Errorf("key column %s has Go type %s, which cannot be parsed from a route parameter (supported: %s and enums)",
+				col.Name, col.Type, strings.Join(supported, ", "))
+		}
+	}
+	return nil
+}
+
+// uniqueImports drops empty and repeated packages, keeping the first of each
+func uniqueImports(imports []string) []string {
+	var out []string
+	seen := map[string]bool{"": true}
+	for _, pkg := range imports {
+		if !seen[pkg] {
+			seen[pkg] = true
+			out = append(out, pkg)
+		}
+	}
+	return out
+}
+
+// Params returns the method parameters of the lookup, e.g. "email string"
+func (f Finder) Params() string {
+	var params []string
+	for _, col := range f.Lookups() {
+		params = append(params, col.Param+" "+col.Type)
+	}
+	return strings.Join(params, ", ")
+}
+
+// Args returns the lookup parameters as call arguments, e.g. "email" or "userID, roleID"
+func (f Finder) Args() string {
+	var args []string
+	for _, col := range f.Lookups() {
+		args = append(args, col.Param)
+	}
+	return strings.Join(args, ", ")
+}
+
+// scoped returns the lookup columns followed by the tenant column, unless it
+// is one of them or the table has none. Queries by parameters are scoped to
+// the tenant.
+func (f Finder) scoped() []FinderColumn {
+	if f.tenant == nil {
+		return f.Columns
+	}
+	for _, col := range f.Columns {
+		if col.Name == scopeColumn {
+			return f.Columns
+		}
+	}
+	return append(f.Columns[:len(f.Columns):len(f.Columns)], *f.tenant)
+}
+
+// Where returns the query condition as a Go string literal, scoped to the
+// tenant when the table has it, e.g. `"email" = ? AND "org_unit_id" = ?`
+func (f Finder) Where() string {
+	var conds []string
+	for _, col := range f.scoped() {
+		conds = append(conds, f.dialect.QuoteIdent(col.Name)+" = ?")
+	}
+	return goString(strings.Join(conds, " AND "))
+}
+
+// Match returns the condition on the lookup columns alone as a Go string
+// literal, for queries that read the values back from an entity with Values
+func (f Finder) Match() string {
+	var conds []string
+	for _, col := range f.Columns {
+		conds = append(conds, f.dialect.QuoteIdent(col.Name)+" = ?")
+	}
+	return goString(strings.Join(conds, " AND "))
+}
+
+// WhereArgs returns the arguments matching the placeholders of Where
+func (f Finder) WhereArgs() string {
+	var args []string
+	for _, col := range f.scoped() {
+		args = append(args, col.Param)
+	}
+	return strings.Join(args, ", ")
+}
+
+// keyed returns the columns of the cache key: the lookup columns, followed by
+// the tenant column when the table has one so a cached record is only returned
+// to its own tenant
+func (f Finder) keyed() []FinderColumn {
+	if f.tenantKeyed {
+		return f.scoped()
+	}
+	return f.Columns
+}
+
+// KeyArgs returns the cache key arguments of a lookup by parameters
+func (f Finder) KeyArgs() string {
+	var args []string
+	for _, col := range f.keyed() {
+		args = append(args, col.Param)
+	}
+	return strings.Join(args, ", ")
+}
+
+// Values returns the lookup column values read from the entity variable v,
+// matching the placeholders of Match
+func (f Finder) Values(v string) string {
+	var args []string
+	for _, col := range f.Columns {
+		args = append(args, v+col.Value)
+	}
+	return strings.Join(args, ", ")
+}
+
+// KeyValues returns the cache key arguments read from the entity variable v,
+// matching KeyArgs. Pointer fields are dereferenced so the key holds the
+// value; KeyCheck guards against nil ones.
+func (f Finder) KeyValues(v string) string {
+	var args []string
+	for _, col := range f.keyed() {
+		if col.pointer {
+			args = append(args, "*"+v+col.Value)
+		} else {
+			args = append(args, v+col.Value)
+		}
+	}
+	return strings.Join(args, ", ")
+}
+
+// KeyCheck returns the condition under which KeyValues can be read from the
+// entity variable v, e.g. "old.OrgUnitID != nil", or "" when the key holds no
+// pointer fields. A record with a nil key value has no cache key to invalidate.
+func (f Finder) KeyCheck(v string) string {
+	var conds []string
+	for _, col := range f.keyed() {
+		if col.pointer {
+			conds = append(conds, v+col.Value+" != nil")
+		}
+	}
+	return strings.Join(conds, " && ")
+}
+
+// Path returns the route parameters of the lookup, e.g. "/:user_id/:role_id"
+func (f Finder) Path() string {
+	var path strings.Builder
+	for _, col := range f.Lookups() {
+		path.WriteString("/:" + col.Name)
+	}
+	return path.String()
+}
+
+// Description returns the lookup columns for doc comments, e.g. "email" or "org_unit_id and slug"
+func (f Finder) Description() string {
+	var names []string
+	for _, col := range f.Columns {
+		names = append(names, col.Name)
+	}
+	return strings.Join(names, " and ")
+}
+
+// KeySuffix returns the redis key action of the lookup, naming the tenant
+// column last when it is part of the key, e.g. "find-by-email-and-org-unit-id"
+// or "find-by-org-unit-id-and-slug"
+func (f Finder) KeySuffix() string {
+	var names []string
+	for _, col := range f.keyed() {
+		names = append(names, strings.ReplaceAll(strings.ToLower(col.Name), "_", "-"))
+	}
+	return "find-by-" + strings.Join(names, "-and-")
+}
+
+// finders returns the FindBy lookups of a table: one per unique column, unique
+// constraint or index, skipping the primary key, lookups by audit, time, float or
+// binary columns and indexes that only cover the tenant column
+func (g *Generator) finders(tbl *types.Table) []Finder {
+	if tbl == nil {
+		return nil
+	}
+
+	var lookups []types.Index
+	for _, col := range tbl.Columns {
+		if col.Unique && !col.PrimaryKey {
+			lookups = append(lookups, types.Index{Columns: []string{col.Name}, Unique: true})
+		}
+	}
+	// unique lookups come first so they win over plain indexes on the same columns
+	for _, unique := range []bool{true, false} {
+		for _, idx := range tbl.Indexes {
+			if idx.Unique == unique {
+				lookups = append(lookups, idx)
+			}
+		}
+	}
+
+	var out []Finder
+	// FindByID is always generated from the primary key
+	seen := map[string]bool{"ID": true}
+	for _, idx := range lookups {
+		f, ok := g.finder(tbl, idx)
+		if !ok || seen[f.Name] {
+			continue
+		}
+		seen[f.Name] = true
+		out = append(out, f)
+	}
+	return out
+}
+
+// finder builds the lookup of an index, or reports false when the index is not a useful lookup
+func (g *Generator) finder(tbl *types.Table, idx types.Index) (Finder, bool) {
+	tenant := g.tenantColumn(tbl)
+	f := Finder{Unique: idx.Unique, tenant: tenant, tenantKeyed: tenant != nil, dialect: g.config.Dialect}
+	var names []string
+	lookup := false
+
+	for _, name := range idx.Columns {
+		col := tbl.FindColumn(name)
+		if col == nil || isAuditable(col.Name) {
+			return Finder{}, false
+		}
+		switch g.mapper().kind(*col) {
+		case kindTime, kindFloat, kindDecimal, kindBytes, kindJSON,
+			kindStringArray, kindIntArray, kindFloatArray, kindBoolArray:
+			// equality lookups on these are rarely meaningful and do not make stable cache keys
+			return Finder{}, false
+		}
+		if g.config.NullableStyle == types.NullablePointer && g.mapper().wrapped(*col) {
+			// a nil pointer makes no cache key
+			return Finder{}, false
+		}
+		lookup = lookup || col.Name != scopeColumn
+
+		names = append(names, naming.Pascal(col.Name))
+		f.Columns = append(f.Columns, g.finderColumn(*col))
+	}
+	if !lookup || samePrimaryKey(tbl, idx.Columns) {
+		return Finder{}, false
+	}
+	f.Name = strings.Join(names, "And")
+	return f, true
+}
+
+// primaryKey returns the lookup of a table by its primary key, which may span
+// several columns. Tables and views without a primary key are looked up by id.
+// Its cache key holds the key columns alone, matching the XFindByID constants
+// of existing projects.
+func (g *Generator) primaryKey(tbl *types.Table) Finder {
+	key := Finder{Name: "ID", Unique: true, tenant: g.tenantColumn(tbl), dialect: g.config.Dialect}
+	if tbl != nil {
+		for _, col := range tbl.Columns {
+			if col.PrimaryKey {
+				key.Columns = append(key.Columns, g.finderColumn(col))
+			}
+		}
+	}
+	if len(key.Columns) > 0 {
+		return key
+	}
+
+	id := types.Column{Name: "id", Type: "UUID"}
+	if tbl != nil {
+		if col := tbl.FindColumn("id"); col != nil {
+			id = *col
+		}
+	}
+	key.Columns = []FinderColumn{g.finderColumn(id)}
+	return key
+}
+
+// tenantColumn returns the tenant column queries are scoped by, or nil when
+// the table has no org_unit_id column, such as join tables and most views
+func (g *Generator) tenantColumn(tbl *types.Table) *FinderColumn {
+	if tbl == nil {
+		return nil
+	}
+	col := tbl.FindColumn(scopeColumn)
+	if col == nil {
+		return nil
+	}
+	tenant := g.finderColumn(*col)
+	return &tenant
+}
+
+// samePrimaryKey reports whether columns are exactly the primary key of the table
+func samePrimaryKey(tbl *types.Table, columns []string) bool {
+	var pk []string
+	for _, col := range tbl.Columns {
+		if col.PrimaryKey {
+			pk = append(pk, col.Name)
+		}
+	}
+	if len(pk) == 0 || len(pk) != len(columns) {
+		return false
+	}
+	for _, name := range columns {
+		col := tbl.FindColumn(name)
+		if col == nil || !col.PrimaryKey {
+			return false
+		}
+	}
+	return true
+}
+
+// finderColumn describes how a lookup column is passed and read back from an entity
+func (g *Generator) finderColumn(col types.Column) FinderColumn {
+	param := localName(naming.Camel(col.Name))
+
+	// lookups compare plain values, so parameters use the non-null type
+	plain := col
+	plain.Nullable = false
+
+	m := g.mapper()
+	value := "." + naming.Pascal(col.Name)
+	pointer := false
+	if m.wrapped(col) {
+		// queries take the pointer as is, database/sql reads the value behind it
+		if g.config.NullableStyle == types.NullablePointer {
+			pointer = true
+		} else {
+			value += ".V"
+		}
+	} else if col.Nullable && col.Enum == nil {
+		switch m.kind(col) {
+		case kindUUID:
+			value += ".UUID"
+		case kindString, kindInet, kindTimeOfDay, kindOther:
+			value += ".String"
+		case kindInt, kindUint:
+			value += ".Int64"
+		case kindBool:
+			value += ".Bool"
+		}
+	}
+
+	fc := FinderColumn{
+		Name:    col.Name,
+		Param:   param,
+		Type:    m.goType(plain),
+		Value:   value,
+		pointer: pointer,
+	}
+	if col.Enum != nil {
+		// enum types live in the entity package and are parsed by it
+		fc.parser = paramParser{call: "entity.Parse" + fc.Type + "({src})"}
+		fc.Type = "entity." + fc.Type
+		fc.parsable, fc.enum = true, true
+		return fc
+	}
+	fc.parser, fc.parsable = paramParsers[fc.Type]
+	for _, pkg := range m.mapping(col).Imports {
+		fc.typeImports = append(fc.typeImports, strings.ReplaceAll(pkg, "{module}", g.config.ModulePath))
+	}
+	return fc
+}
+
+// goString returns s as a Go string literal, raw unless s holds a backtick
+func goString(s string) string {
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
+
+// cachedFinders returns the unique lookups, which are cached like FindByID
+func cachedFinders(finders []Finder) []Finder {
+	var out []Finder
+	for _, f := range finders {
+		if f.Unique {
+			out = append(out, f)
+		}
+	}
+	return out
+}
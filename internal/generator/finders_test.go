@@ -0,0 +1,561 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rifqiakrm/starter-cli/internal/config"
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+func TestFinders(t *testing.T) {
+	tbl := &types.Table{
+		Name: "users",
+		Columns: []types.Column{
+			{Name: "id", Type: "UUID", PrimaryKey: true},
+			{Name: "org_unit_id", Type: "UUID"},
+			{Name: "email", Type: "VARCHAR(255)", Unique: true},
+			{Name: "slug", Type: "VARCHAR(100)"},
+			{Name: "role_id", Type: "UUID"},
+			{Name: "balance", Type: "DOUBLE PRECISION"},
+			{Name: "avatar", Type: "BYTEA"},
+			{Name: "born_at", Type: "TIMESTAMPTZ"},
+			{Name: "created_by", Type: "UUID"},
+		},
+		Indexes: []types.Index{
+			{Name: "users_role_id_idx", Columns: []string{"role_id"}},
+			{Name: "users_org_unit_id_idx", Columns: []string{"org_unit_id"}},
+			{Name: "users_id_idx", Columns: []string{"id"}, Unique: true},
+			{Name: "users_balance_idx", Columns: []string{"balance"}},
+			{Name: "users_avatar_idx", Columns: []string{"avatar"}},
+			{Name: "users_born_at_idx", Columns: []string{"born_at"}},
+			{Name: "users_created_by_idx", Columns: []string{"created_by"}},
+			{Name: "users_email_idx", Columns: []string{"email"}},
+			{Name: "users_org_unit_id_slug_key", Columns: []string{"org_unit_id", "slug"}, Unique: true},
+		},
+	}
+
+	g := NewGenerator(&config.Config{Dialect: types.DialectPostgres})
+	var got []string
+	unique := map[string]bool{}
+	for _, f := range g.finders(tbl) {
+		got = append(got, f.Name)
+		unique[f.Name] = f.Unique
+	}
+
+	// the primary key, the tenant-only index and the audit, time, float and
+	// binary columns are skipped; the plain index on email loses to the unique one
+	want := []string{"Email", "OrgUnitIDAndSlug", "RoleID"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("finders() = %q, want %q", got, want)
+	}
+	wantUnique := map[string]bool{"Email": true, "OrgUnitIDAndSlug": true, "RoleID": false}
+	if !reflect.DeepEqual(unique, wantUnique) {
+		t.Errorf("finders() unique = %v, want %v", unique, wantUnique)
+	}
+
+	var cached []string
+	for _, f := range cachedFinders(g.finders(tbl)) {
+		cached = append(cached, f.Name)
+	}
+	if want := []string{"Email", "OrgUnitIDAndSlug"}; !reflect.DeepEqual(cached, want) {
+		t.Errorf("cachedFinders() = %q, want %q", cached, want)
+	}
+}
+
+func TestFinderQueries(t *testing.T) {
+	tbl := &types.Table{
+		Name: "users",
+		Columns: []types.Column{
+			{Name: "id", Type: "UUID", PrimaryKey: true},
+			{Name: "org_unit_id", Type: "UUID"},
+			{Name: "email", Type: "VARCHAR(255)", Unique: true, Nullable: true},
+			{Name: "slug", Type: "VARCHAR(100)"},
+		},
+		Indexes: []types.Index{
+			{Name: "users_org_unit_id_slug_key", Columns: []string{"org_unit_id", "slug"}, Unique: true},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		dialect   types.Dialect
+		finder    string
+		where     string
+		match     string
+		whereArgs string
+		values    string
+		keyValues string
+		keySuffix string
+	}{
+		{
+			name:      "scoped to the tenant",
+			dialect:   types.DialectPostgres,
+			finder:    "Email",
+			where:     "`\"email\" = ? AND \"org_unit_id\" = ?`",
+			match:     "`\"email\" = ?`",
+			whereArgs: "email, orgUnitID",
+			values:    "e.Email.String",
+			keyValues: "e.Email.String, e.OrgUnitID",
+			keySuffix: "find-by-email-and-org-unit-id",
+		},
+		{
+			name:      "tenant looked up",
+			dialect:   types.DialectPostgres,
+			finder:    "OrgUnitIDAndSlug",
+			where:     "`\"org_unit_id\" = ? AND \"slug\" = ?`",
+			match:     "`\"org_unit_id\" = ? AND \"slug\" = ?`",
+			whereArgs: "orgUnitID, slug",
+			values:    "e.OrgUnitID, e.Slug",
+			keyValues: "e.OrgUnitID, e.Slug",
+			keySuffix: "find-by-org-unit-id-and-slug",
+		},
+		{
+			name:      "mysql quoting",
+			dialect:   types.DialectMySQL,
+			finder:    "Email",
+			where:     "\"`email` = ? AND `org_unit_id` = ?\"",
+			match:     "\"`email` = ?\"",
+			whereArgs: "email, orgUnitID",
+			values:    "e.Email.String",
+			keyValues: "e.Email.String, e.OrgUnitID",
+			keySuffix: "find-by-email-and-org-unit-id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var f Finder
+			for _, finder := range NewGenerator(&config.Config{Dialect: tt.dialect, NullableStyle: types.NullableSQLNull}).finders(tbl) {
+				if finder.Name == tt.finder {
+					f = finder
+				}
+			}
+			if f.Name == "" {
+				t.Fatalf("no finder %s", tt.finder)
+			}
+
+			if got := f.Where(); got != tt.where {
+				t.Errorf("Where() = %s, want %s", got, tt.where)
+			}
+			if got := f.Match(); got != tt.match {
+				t.Errorf("Match() = %s, want %s", got, tt.match)
+			}
+			if got := f.WhereArgs(); got != tt.whereArgs {
+				t.Errorf("WhereArgs() = %q, want %q", got, tt.whereArgs)
+			}
+			if got := f.KeyArgs(); got != tt.whereArgs {
+				t.Errorf("KeyArgs() = %q, want %q", got, tt.whereArgs)
+			}
+			if got := f.Values("e"); got != tt.values {
+				t.Errorf("Values() = %q, want %q", got, tt.values)
+			}
+			if got := f.KeyValues("e"); got != tt.keyValues {
+				t.Errorf("KeyValues() = %q, want %q", got, tt.keyValues)
+			}
+			if got := f.KeySuffix(); got != tt.keySuffix {
+				t.Errorf("KeySuffix() = %q, want %q", got, tt.keySuffix)
+			}
+		})
+	}
+}
+
+func TestFinderTenantOfTableWithoutColumn(t *testing.T) {
+	tbl := &types.Table{
+		Name: "tags",
+		Columns: []types.Column{
+			{Name: "id", Type: "UUID", PrimaryKey: true},
+			{Name: "label", Type: "TEXT", Unique: true},
+		},
+	}
+
+	g := NewGenerator(&config.Config{Dialect: types.DialectPostgres})
+	key := g.primaryKey(tbl)
+	f := g.finders(tbl)[0]
+	tests := []struct {
+		method string
+		got    string
+		want   string
+	}{
+		{"Key.Where", key.Where(), "`\"id\" = ?`"},
+		{"Key.WhereArgs", key.WhereArgs(), "id"},
+		{"Key.KeyArgs", key.KeyArgs(), "id"},
+		{"Key.KeySuffix", key.KeySuffix(), "find-by-id"},
+		{"Where", f.Where(), "`\"label\" = ?`"},
+		{"WhereArgs", f.WhereArgs(), "label"},
+		{"KeyArgs", f.KeyArgs(), "label"},
+		{"KeyValues", f.KeyValues("old"), "old.Label"},
+		{"KeySuffix", f.KeySuffix(), "find-by-label"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s() = %s, want %s", tt.method, tt.got, tt.want)
+		}
+	}
+}
+
+func TestRepositoriesWithoutTenant(t *testing.T) {
+	tbl := &types.Table{
+		Schema:    "public",
+		Name:      "tags",
+		NameUpper: "Tag",
+		NameLower: "tag",
+		Columns: []types.Column{
+			{Name: "id", Type: "UUID", PrimaryKey: true},
+			{Name: "label", Type: "TEXT", Unique: true},
+		},
+	}
+
+	g := NewGenerator(&config.Config{Dialect: types.DialectPostgres, ModulePath: "example.com/app", TemplatePaths: config.TemplatePaths{
+		RepositoryCreator: "templates/module/repository/creator.tmpl",
+		RepositoryFinder:  "templates/module/repository/finder.tmpl",
+		RepositoryUpdater: "templates/module/repository/updater.tmpl",
+		RepositoryDeleter: "templates/module/repository/deleter.tmpl",
+		ServiceFinder:     "templates/module/service/finder.tmpl",
+	}})
+	repos, err := g.generateRepositories("public", "tags", "v1", "", t.TempDir(), tbl)
+	if err != nil {
+		t.Fatalf("generateRepositories() error = %v", err)
+	}
+	services, err := g.generateServices("public", "tags", "v1", "finder", t.TempDir(), tbl)
+	if err != nil {
+		t.Fatalf("generateServices() error = %v", err)
+	}
+
+	tests := []struct {
+		file string
+		code string
+		want []string
+	}{
+		{
+			file: "finder repository",
+			code: repos[1].code,
+			want: []string{
+				`"github.com/google/uuid"`,
+				"FindByID(ctx context.Context, id uuid.UUID, includeDeleted bool)",
+				"query.First(&e, `\"id\" = ?`, id)",
+				"FindAll(ctx context.Context, limit, offset int)",
+				"Model(&entity.Tag{}).Count(&total)",
+				"FindByLabel(ctx context.Context, label string)",
+				"First(&e, `\"label\" = ?`, label)",
+			},
+		},
+		{
+			file: "deleter repository",
+			code: repos[3].code,
+			want: []string{
+				"Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID)",
+				"Where(`\"id\" = ?`, id)",
+			},
+		},
+		{
+			file: "finder service",
+			code: services[0].code,
+			want: []string{
+				"svc.repo.FindByID(ctx, id, false)",
+				"svc.repo.FindAll(ctx, limit, offset)",
+				"svc.repo.FindByLabel(ctx, label)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		for _, want := range tt.want {
+			if !strings.Contains(tt.code, want) {
+				t.Errorf("%s does not contain %s:\n%s", tt.file, want, tt.code)
+			}
+		}
+		// services keep the orgUnitID the handlers pass, but do not query by it
+		if strings.Contains(tt.code, "org_unit_id") || tt.file != "finder service" && strings.Contains(tt.code, "orgUnitID") {
+			t.Errorf("%s is scoped to a tenant column the table does not have:\n%s", tt.file, tt.code)
+		}
+	}
+}
+
+// userRoles is keyed by two columns, which a unique index repeats
+func userRoles() *types.Table {
+	return &types.Table{
+		Schema:    "auth",
+		Name:      "user_roles",
+		NameUpper: "UserRole",
+		NameLower: "userRole",
+		Columns: []types.Column{
+			{Name: "user_id", Type: "UUID", PrimaryKey: true},
+			{Name: "role_id", Type: "BIGINT", PrimaryKey: true},
+			{Name: "org_unit_id", Type: "UUID"},
+			{Name: "granted_by", Type: "TEXT"},
+		},
+		Indexes: []types.Index{
+			{Name: "user_roles_role_id_user_id_key", Columns: []string{"role_id", "user_id"}, Unique: true},
+		},
+	}
+}
+
+func TestCompositePrimaryKey(t *testing.T) {
+	g := NewGenerator(&config.Config{Dialect: types.DialectPostgres})
+	tbl := userRoles()
+	key := g.primaryKey(tbl)
+
+	tests := []struct {
+		method string
+		got    string
+		want   string
+	}{
+		{"Name", key.Name, "ID"},
+		{"Path", key.Path(), "/:user_id/:role_id"},
+		{"Where", key.Where(), "`\"user_id\" = ? AND \"role_id\" = ? AND \"org_unit_id\" = ?`"},
+		{"WhereArgs", key.WhereArgs(), "userID, roleID, orgUnitID"},
+		{"Params", key.Params(), "userID uuid.UUID, roleID int64"},
+		{"Args", key.Args(), "userID, roleID"},
+		{"Match", key.Match(), "`\"user_id\" = ? AND \"role_id\" = ?`"},
+		{"Values", key.Values("e"), "e.UserID, e.RoleID"},
+		{"KeyArgs", key.KeyArgs(), "userID, roleID"},
+		{"KeyValues", key.KeyValues("e"), "e.UserID, e.RoleID"},
+		{"KeySuffix", key.KeySuffix(), "find-by-user-id-and-role-id"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s() = %s, want %s", tt.method, tt.got, tt.want)
+		}
+	}
+	if got, want := key.Imports(), []string{"github.com/google/uuid", "strconv"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Imports() = %q, want %q", got, want)
+	}
+
+	// the unique index on the key columns in another order is no extra lookup
+	for _, f := range g.finders(tbl) {
+		t.Errorf("finders() = %s, want none", f.Name)
+	}
+	// GORM only assumes a primary key for the ID field
+	if got, want := gormTag(tbl.Columns[1]), `gorm:"primaryKey" `; got != want {
+		t.Errorf("gormTag(role_id) = %s, want %s", got, want)
+	}
+}
+
+func TestCompositePrimaryKeyTemplates(t *testing.T) {
+	g := NewGenerator(&config.Config{Dialect: types.DialectPostgres, ModulePath: "example.com/app", TemplatePaths: config.TemplatePaths{
+		RepositoryFinder: "templates/module/repository/finder.tmpl",
+		HandlerFinder:    "templates/module/handler/finder.tmpl",
+	}})
+	repos, err := g.generateRepositories("auth", "user_roles", "v1", "finder", t.TempDir(), userRoles())
+	if err != nil {
+		t.Fatalf("generateRepositories() error = %v", err)
+	}
+	handlers, err := g.generateHandlers("auth", "user_roles", "v1", "finder", t.TempDir(), userRoles())
+	if err != nil {
+		t.Fatalf("generateHandlers() error = %v", err)
+	}
+
+	tests := []struct {
+		file string
+		code string
+		want []string
+	}{
+		{
+			file: "repository",
+			code: repos[0].code,
+			want: []string{
+				"FindByID(ctx context.Context, orgUnitID uuid.UUID, userID uuid.UUID, roleID int64, includeDeleted bool)",
+				"query.First(&e, `\"user_id\" = ? AND \"role_id\" = ? AND \"org_unit_id\" = ?`, userID, roleID, orgUnitID)",
+			},
+		},
+		{
+			file: "handler",
+			code: handlers[0].code,
+			want: []string{
+				`userID, err := uuid.Parse(c.Param("user_id"))`,
+				`roleID, err := strconv.ParseInt(c.Param("role_id"), 10, 64)`,
+				"GetUserRoleByID(c, orgUnitID, userID, roleID, executorID)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		for _, want := range tt.want {
+			if !strings.Contains(tt.code, want) {
+				t.Errorf("%s does not contain %s:\n%s", tt.file, want, tt.code)
+			}
+		}
+	}
+}
+
+func TestFinderNullableTenant(t *testing.T) {
+	tbl := &types.Table{
+		Name: "users",
+		Columns: []types.Column{
+			{Name: "id", Type: "UUID", PrimaryKey: true},
+			{Name: "org_unit_id", Type: "UUID", Nullable: true},
+			{Name: "email", Type: "TEXT", Unique: true},
+		},
+	}
+
+	tests := []struct {
+		style     types.NullableStyle
+		keyValues string
+		keyCheck  string
+		values    string
+	}{
+		{types.NullableSQLNull, "e.Email, e.OrgUnitID.UUID", "", "e.Email"},
+		{types.NullableGeneric, "e.Email, e.OrgUnitID.V", "", "e.Email"},
+		{types.NullablePointer, "e.Email, *e.OrgUnitID", "e.OrgUnitID != nil", "e.Email"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.style), func(t *testing.T) {
+			g := NewGenerator(&config.Config{Dialect: types.DialectPostgres, NullableStyle: tt.style})
+			finders := g.finders(tbl)
+			if len(finders) != 1 || finders[0].Name != "Email" {
+				t.Fatalf("finders() = %+v, want Email", finders)
+			}
+			if got := finders[0].KeyValues("e"); got != tt.keyValues {
+				t.Errorf("KeyValues() = %s, want %s", got, tt.keyValues)
+			}
+			if got := finders[0].KeyCheck("e"); got != tt.keyCheck {
+				t.Errorf("KeyCheck() = %s, want %s", got, tt.keyCheck)
+			}
+			if got := finders[0].Values("e"); got != tt.values {
+				t.Errorf("Values() = %s, want %s", got, tt.values)
+			}
+		})
+	}
+
+	// a NULL tenant of the stored or updated record is never dereferenced
+	g := NewGenerator(&config.Config{Dialect: types.DialectPostgres, NullableStyle: types.NullablePointer, ModulePath: "example.com/app",
+		TemplatePaths: config.TemplatePaths{RepositoryUpdater: "templates/module/repository/updater.tmpl"}})
+	tbl.Schema, tbl.NameUpper, tbl.NameLower = "auth", "User", "user"
+	repos, err := g.generateRepositories("auth", "users", "v1", "updater", t.TempDir(), tbl)
+	if err != nil {
+		t.Fatalf("generateRepositories() error = %v", err)
+	}
+	var guarded []string
+	for _, line := range strings.Split(repos[0].code, "\n") {
+		if strings.Contains(line, "OrgUnitID != nil") {
+			guarded = append(guarded, strings.TrimSpace(line))
+		}
+	}
+	if want := []string{"if old.OrgUnitID != nil {", "if e.OrgUnitID != nil {"}; !reflect.DeepEqual(guarded, want) {
+		t.Errorf("nil checks = %q, want %q:\n%s", guarded, want, repos[0].code)
+	}
+}
+
+func TestFinderColumnParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		col      types.Column
+		typ      string
+		parse    string
+		parsable bool
+	}{
+		{"bigserial", types.Column{Name: "id", Type: "BIGSERIAL", Identity: true}, "int64", `strconv.ParseInt(c.Param("id"), 10, 64)`, true},
+		{"bigint", types.Column{Name: "id", Type: "BIGINT"}, "int64", `strconv.ParseInt(c.Param("id"), 10, 64)`, true},
+		{"text", types.Column{Name: "id", Type: "TEXT"}, "string", "", true},
+		{"nullable varchar", types.Column{Name: "id", Type: "VARCHAR(26)", Nullable: true}, "string", "", true},
+		{"uuid", types.Column{Name: "id", Type: "UUID"}, "uuid.UUID", `uuid.Parse(c.Param("id"))`, true},
+		{"ulid", types.Column{Name: "id", Type: "ULID"}, "ulid.ULID", `ulid.Parse(c.Param("id"))`, true},
+		{"boolean", types.Column{Name: "id", Type: "BOOLEAN"}, "bool", `strconv.ParseBool(c.Param("id"))`, true},
+		{"enum", types.Column{Name: "id", Type: "KIND", Enum: &types.Enum{Name: "kind"}}, "entity.Kind", `entity.ParseKind(c.Param("id"))`, true},
+		{"mapped to uint64", types.Column{Name: "id", Type: "SNOWFLAKE"}, "uint64", `strconv.ParseUint(c.Param("id"), 10, 64)`, true},
+		{"mapped to a struct", types.Column{Name: "id", Type: "MONEY"}, "money.Money", "", false},
+	}
+
+	g := NewGenerator(&config.Config{Dialect: types.DialectPostgres, NullableStyle: types.NullableSQLNull, TypeMappings: map[string]config.TypeMapping{
+		"snowflake": {GoType: "uint64"},
+		"money":     {GoType: "money.Money", Imports: []string{"example.com/money"}},
+	}})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			col := g.finderColumn(tt.col)
+			if col.Type != tt.typ {
+				t.Errorf("Type = %s, want %s", col.Type, tt.typ)
+			}
+			if got := col.Parse(`c.Param("id")`); got != tt.parse {
+				t.Errorf("Parse() = %s, want %s", got, tt.parse)
+			}
+			if col.parsable != tt.parsable {
+				t.Errorf("parsable = %v, want %v", col.parsable, tt.parsable)
+			}
+		})
+	}
+}
+
+func TestKeyParseError(t *testing.T) {
+	g := NewGenerator(&config.Config{Dialect: types.DialectPostgres, TypeMappings: map[string]config.TypeMapping{
+		"money": {GoType: "money.Money"},
+	}})
+
+	tests := []struct {
+		name    string
+		columns []types.Column
+		wantErr string
+	}{
+		{
+			name:    "int64 key",
+			columns: []types.Column{{Name: "id", Type: "BIGSERIAL", PrimaryKey: true, Identity: true}},
+		},
+		{
+			name:    "string and ulid key",
+			columns: []types.Column{{Name: "code", Type: "TEXT", PrimaryKey: true}, {Name: "ref", Type: "ULID", PrimaryKey: true}},
+		},
+		{
+			// the tenant is taken from the request context, not from the route
+			name:    "tenant in the key",
+			columns: []types.Column{{Name: "org_unit_id", Type: "MONEY", PrimaryKey: true}, {Name: "id", Type: "BIGINT", PrimaryKey: true}},
+		},
+		{
+			name:    "unparsable key",
+			columns: []types.Column{{Name: "id", Type: "BIGINT", PrimaryKey: true}, {Name: "amount", Type: "MONEY", PrimaryKey: true}},
+			wantErr: "key column amount has Go type money.Money, which cannot be parsed from a route parameter " +
+				"(supported: bool, float64, int64, string, uint64, ulid.ULID, uuid.UUID and enums)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := g.primaryKey(&types.Table{Name: "items", Columns: tt.columns}).parseError()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("parseError() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("parseError() = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+
+	// the handlers reading the key from the route refuse to generate
+	tbl := &types.Table{Name: "items", Columns: []types.Column{{Name: "id", Type: "MONEY", PrimaryKey: true}}}
+	if _, err := g.generateHandlers("public", "items", "v1", "finder", t.TempDir(), tbl); err == nil ||
+		!strings.HasPrefix(err.Error(), "handler finder: key column id") {
+		t.Errorf("generateHandlers() error = %v, want a key column error", err)
+	}
+}
+
+func TestCacheKeyConstants(t *testing.T) {
+	g := NewGenerator(&config.Config{Dialect: types.DialectPostgres})
+	tbl := &types.Table{
+		Name: "users",
+		Columns: []types.Column{
+			{Name: "id", Type: "UUID", PrimaryKey: true},
+			{Name: "org_unit_id", Type: "UUID"},
+			{Name: "email", Type: "TEXT", Unique: true},
+		},
+	}
+	data := TemplateData{EntityUpper: "User", Key: g.primaryKey(tbl), CachedFinders: cachedFinders(g.finders(tbl))}
+
+	got := g.generateCacheKeyConstants("const (\n)", "auth", "users", data)
+	for _, want := range []string{
+		`UserFindByID = prefix + ":auth:users:find-by-id:%v"`,
+		`UserFindByEmail = prefix + ":auth:users:find-by-email-and-org-unit-id:%v:%v"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("generateCacheKeyConstants() = %s, want it to contain %s", got, want)
+		}
+	}
+
+	// the FindByID key of existing projects is kept as is
+	existing := "const (\n\tUserFindByID = prefix + \":auth:users:find-by-id:%v\"\n)"
+	if got := g.generateCacheKeyConstants(existing, "auth", "users", data); strings.Contains(got, "UserFindByID") {
+		t.Errorf("generateCacheKeyConstants() = %s, want UserFindByID left out", got)
+	}
+}
@@ -0,0 +1,129 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"sort"
+	"strings"
+)
+
+// formatGo gofmts generated Go code and groups its imports into the standard
+// library, third-party packages and the packages under the local prefix. Code
+// that does not parse is rejected with the offending line, so a broken
+// template never leaves a broken file behind.
+func (g *Generator) formatGo(code string) (string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", code, parser.ParseComments)
+	if err != nil {
+		return "", syntaxError(code, err)
+	}
+
+	// Rewrite the import blocks from the last one, so the offsets of the
+	// earlier ones stay valid
+	src := []byte(code)
+	for i := len(file.Decls) - 1; i >= 0; i-- {
+		decl, ok := file.Decls[i].(*ast.GenDecl)
+		if !ok || decl.Tok != token.IMPORT || !decl.Lparen.IsValid() || hasComments(file, decl) {
+			continue
+		}
+		start, end := fset.Position(decl.Lparen).Offset+1, fset.Position(decl.Rparen).Offset
+		block := g.groupImports(decl.Specs)
+		src = append(src[:start:start], append([]byte(block), src[end:]...)...)
+	}
+
+	out, err := format.Source(src)
+	if err != nil {
+		return "", syntaxError(string(src), err)
+	}
+	return string(out), nil
+}
+
+// groupImports renders import specs as blank line separated groups of
+// standard library, third-party and local packages, each sorted by path
+func (g *Generator) groupImports(specs []ast.Spec) string {
+	var std, thirdParty, local []string
+	seen := map[string]bool{}
+	for _, spec := range specs {
+		imp := spec.(*ast.ImportSpec)
+		line := imp.Path.Value
+		if imp.Name != nil {
+			line = imp.Name.Name + " " + line
+		}
+		if seen[line] {
+			continue
+		}
+		seen[line] = true
+
+		switch path := strings.Trim(imp.Path.Value, "`\""); {
+		case g.isLocalImport(path):
+			local = append(local, line)
+		case isStdImport(path):
+			std = append(std, line)
+		default:
+			thirdParty = append(thirdParty, line)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("\n")
+	for _, group := range [][]string{std, thirdParty, local} {
+		if len(group) == 0 {
+			continue
+		}
+		sort.Slice(group, func(i, j int) bool { return importPath(group[i]) < importPath(group[j]) })
+		if b.Len() > 1 {
+			b.WriteString("\n")
+		}
+		for _, line := range group {
+			b.WriteString("\t" + line + "\n")
+		}
+	}
+	return b.String()
+}
+
+// isLocalImport reports whether an import path is a package of the project,
+// i.e. under the local prefix or else the module path
+func (g *Generator) isLocalImport(path string) bool {
+	prefix := g.config.LocalPrefix
+	if prefix == "" {
+		prefix = g.config.ModulePath
+	}
+	prefix = strings.TrimSuffix(prefix, "/")
+	return prefix != "" && (path == prefix || strings.HasPrefix(path, prefix+"/"))
+}
+
+// importPath returns the path of a rendered import spec, without its name
+func importPath(line string) string {
+	return line[strings.IndexAny(line, "`\""):]
+}
+
+// hasComments reports whether comments appear inside a declaration, which
+// regrouping its specs would lose
+func hasComments(file *ast.File, decl *ast.GenDecl) bool {
+	for _, group := range file.Comments {
+		if group.Pos() > decl.Pos() && group.End() < decl.End() {
+			return true
+		}
+	}
+	return false
+}
+
+// syntaxError describes a parse error of generated code with its line
+func syntaxError(code string, err error) error {
+	list, ok := err.(scanner.ErrorList)
+	if !ok || len(list) == 0 {
+		return fmt.Errorf("invalid Go: %v", err)
+	}
+
+	first := list[0]
+	lines := strings.Split(code, "\n")
+	text := ""
+	if first.Pos.Line >= 1 && first.Pos.Line <= len(lines) {
+		text = strings.TrimSpace(lines[first.Pos.Line-1])
+	}
+	return fmt.Errorf("invalid Go at line %d: %s\n\t%d | %s", first.Pos.Line, first.Msg, first.Pos.Line, text)
+}
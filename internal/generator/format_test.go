@@ -0,0 +1,66 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rifqiakrm/starter-cli/internal/config"
+)
+
+func TestFormatGo(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		code   string
+		want   string
+	}{
+		{
+			name: "groups standard, third-party and local imports",
+			code: "package user\nimport (\n\"github.com/google/uuid\"\n\"github.com/acme/shop/common/constant\"\n\"time\"\n\"context\"\n\"time\"\n)\nvar _ = 1\n",
+			want: "package user\n\nimport (\n\t\"context\"\n\t\"time\"\n\n\t\"github.com/google/uuid\"\n\n\t\"github.com/acme/shop/common/constant\"\n)\n\nvar _ = 1\n",
+		},
+		{
+			name:   "local prefix narrows the local group",
+			prefix: "github.com/acme/shop/modules",
+			code:   "package user\nimport (\n\"github.com/acme/shop/modules/auth/entity\"\n\"github.com/acme/shop/common/constant\"\n)\n",
+			want:   "package user\n\nimport (\n\t\"github.com/acme/shop/common/constant\"\n\n\t\"github.com/acme/shop/modules/auth/entity\"\n)\n",
+		},
+		{
+			name: "named imports sort by path",
+			code: "package user\nimport (\nzap \"go.uber.org/zap\"\ncommonEntity \"github.com/acme/shop/common/entity\"\nerrs \"errors\"\n)\n",
+			want: "package user\n\nimport (\n\terrs \"errors\"\n\n\tzap \"go.uber.org/zap\"\n\n\tcommonEntity \"github.com/acme/shop/common/entity\"\n)\n",
+		},
+		{
+			name: "blocks with comments are left as written",
+			code: "package user\nimport (\n\"time\"\n// the UUID type\n\"github.com/google/uuid\"\n\"context\"\n)\n",
+			want: "package user\n\nimport (\n\t\"time\"\n\t// the UUID type\n\t\"context\"\n\t\"github.com/google/uuid\"\n)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGenerator(&config.Config{ModulePath: "github.com/acme/shop", LocalPrefix: tt.prefix})
+			got, err := g.formatGo(tt.code)
+			if err != nil {
+				t.Fatalf("formatGo() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("formatGo() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatGoSyntaxError(t *testing.T) {
+	g := NewGenerator(&config.Config{ModulePath: "github.com/acme/shop"})
+	_, err := g.formatGo("package user\n\nfunc Find() {\n\tx := )\n}\n")
+	if err == nil {
+		t.Fatal("formatGo() error = nil")
+	}
+	if want := "invalid Go at line 4: "; !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("formatGo() error = %q, want prefix %q", err, want)
+	}
+	if want := "\t4 | x := )"; !strings.HasSuffix(err.Error(), want) {
+		t.Errorf("formatGo() error = %q, want suffix %q", err, want)
+	}
+}
@@ -2,16 +2,26 @@ package generator
 
 import (
 	"github.com/rifqiakrm/starter-cli/internal/config"
+	"github.com/rifqiakrm/starter-cli/internal/inflection"
+	"github.com/rifqiakrm/starter-cli/internal/naming"
 )
 
 // Generator holds the main generation logic
 type Generator struct {
 	config *config.Config
+	warned map[string]bool // schema warnings already printed in this run
 }
 
 // NewGenerator creates a new generator instance
 func NewGenerator(cfg *config.Config) *Generator {
+	for singular, plural := range cfg.Inflections.Irregular {
+		inflection.AddIrregular(singular, plural)
+	}
+	inflection.AddUncountable(cfg.Inflections.Uncountable...)
+	naming.AddInitialisms(cfg.Initialisms...)
+
 	return &Generator{
 		config: cfg,
+		warned: make(map[string]bool),
 	}
 }
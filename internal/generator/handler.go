@@ -2,21 +2,29 @@ package generator
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
 )
 
 // generateHandlers generates handler components
-func (g *Generator) generateHandlers(schema, entity, version, action, outputDir string) error {
-	actions := getActions(action)
-	data := g.createTemplateData(schema, entity, version)
+func (g *Generator) generateHandlers(schema, entity, version, action, outputDir string, tbl *types.Table) ([]outputFile, error) {
+	actions := getTableActions(action, "handler", tbl)
+	data := g.createTemplateData(schema, entity, version, tbl)
+	for _, act := range actions {
+		if act == "creator" {
+			continue
+		}
+		// the other handlers read the key from the route
+		if err := data.Key.parseError(); err != nil {
+			return nil, fmt.Errorf("handler %s: %v", act, err)
+		}
+	}
 
 	dir := filepath.Join(outputDir, schema, version, "handler")
-	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
-		return err
-	}
 
+	var files []outputFile
 	for _, act := range actions {
 		var templatePath string
 		switch act {
@@ -29,21 +37,17 @@ func (g *Generator) generateHandlers(schema, entity, version, action, outputDir
 		case "deleter":
 			templatePath = g.config.TemplatePaths.HandlerDeleter
 		default:
-			return fmt.Errorf("unknown handler action: %s", act)
+			return nil, fmt.Errorf("unknown handler action: %s", act)
 		}
 
 		code, err := g.generateFromTemplate("handler_"+act, data, templatePath)
 		if err != nil {
-			return fmt.Errorf("handler %s template error: %v", act, err)
+			return nil, fmt.Errorf("handler %s template error: %v", act, err)
 		}
 
 		filename := filepath.Join(dir, fmt.Sprintf("%s_%s.handler.go", strings.ToLower(entity), act))
-		if err := os.WriteFile(filename, []byte(code), 0644); err != nil {
-			return fmt.Errorf("write handler %s error: %v", act, err)
-		}
-
-		fmt.Printf("✅ Generated handler.%s: %s\n", act, filename)
+		files = append(files, outputFile{path: filename, code: code, label: "handler." + act})
 	}
 
-	return nil
+	return files, nil
 }
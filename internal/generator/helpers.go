@@ -1,30 +1,63 @@
 package generator
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/rifqiakrm/starter-cli/internal/inflection"
+	"github.com/rifqiakrm/starter-cli/internal/naming"
 	"github.com/rifqiakrm/starter-cli/internal/types"
 )
 
 // TemplateData holds data passed to templates
 type TemplateData struct {
+	ModulePath      string // import path of the project module, e.g. github.com/acme/billing
 	Schema          string
 	Version         string
 	EntityCamelCase string
 	EntityLower     string
 	EntityUpper     string
+	Table           *types.Table
+	Key             Finder   // primary key lookup behind FindByID, Update and Delete
+	Finders         []Finder // FindBy lookups from unique constraints and indexes
+	CachedFinders   []Finder // unique lookups, cached and invalidated like FindByID
+	Tenant          bool     // whether the table has the org_unit_id column queries are scoped by
+}
+
+// TableData holds data passed to the entity and resource templates
+type TableData struct {
+	*types.Table
+	ModulePath string // import path of the project module, e.g. github.com/acme/billing
 }
 
 // createTemplateData creates template data from entity name
-func (g *Generator) createTemplateData(schema, entity, version string) TemplateData {
-	singular := singularize(entity)
+func (g *Generator) createTemplateData(schema, entity, version string, tbl *types.Table) TemplateData {
+	singular := inflection.Singular(entity)
+	finders := g.finders(tbl)
+	key := g.primaryKey(tbl)
+	key.entityImport = g.config.ModulePath + "/modules/" + schema + "/entity"
 	return TemplateData{
+		ModulePath:      g.config.ModulePath,
 		Schema:          schema,
 		Version:         version,
-		EntityCamelCase: toCamelCase(singular),
+		EntityCamelCase: naming.Camel(singular),
 		EntityLower:     strings.ToLower(singular),
-		EntityUpper:     toPascalCase(singular),
+		EntityUpper:     naming.Pascal(singular),
+		Table:           tbl,
+		Key:             key,
+		Finders:         finders,
+		CachedFinders:   cachedFinders(finders),
+		Tenant:          key.tenant != nil,
+	}
+}
+
+// tableName returns the name an entity's TableName() reports. SQLite has no
+// schemas, so its tables are left unqualified.
+func (g *Generator) tableName(data TableData) string {
+	if g.config.Dialect == types.DialectSQLite || data.Schema == "" {
+		return data.Name
 	}
+	return data.Schema + "." + data.Name
 }
 
 // getActions returns specific actions or all if empty
@@ -35,148 +68,35 @@ func getActions(action string) []string {
 	return []string{action}
 }
 
-// toCamelCase converts snake_case to camelCase
-func toCamelCase(s string) string {
-	parts := strings.Split(s, "_")
-	for i, p := range parts {
-		if len(p) > 0 {
-			if i == 0 {
-				parts[i] = strings.ToLower(p)
-			} else {
-				parts[i] = strings.ToUpper(p[:1]) + p[1:]
-			}
-		}
+// getTableActions returns the actions to generate for a table.
+// Views are read-only, so only the finder is generated for them.
+func getTableActions(action, component string, tbl *types.Table) []string {
+	actions := getActions(action)
+	if tbl == nil || !tbl.IsView {
+		return actions
 	}
-	return strings.Join(parts, "")
-}
 
-// toPascalCase converts snake_case to PascalCase
-func toPascalCase(s string) string {
-	parts := strings.Split(s, "_")
-	for i, p := range parts {
-		if len(p) > 0 {
-			// Handle special cases like id, ip, url, etc.
-			word := strings.ToLower(p)
-			switch word {
-			case "id":
-				parts[i] = "ID"
-			case "ip":
-				parts[i] = "IP"
-			case "url":
-				parts[i] = "URL"
-			case "api":
-				parts[i] = "API"
-			case "http":
-				parts[i] = "HTTP"
-			case "html":
-				parts[i] = "HTML"
-			case "json":
-				parts[i] = "JSON"
-			case "xml":
-				parts[i] = "XML"
-			default:
-				parts[i] = strings.ToUpper(p[:1]) + p[1:]
-			}
+	var out []string
+	for _, act := range actions {
+		if act == "finder" {
+			out = append(out, act)
+			continue
 		}
+		fmt.Printf("⏭️  Skipping %s.%s: %s is a view\n", component, act, tbl.Name)
 	}
-	return strings.Join(parts, "")
-}
-
-func toLowerCamelCase(s string) string {
-	camel := toCamelCase(s)
-	if len(camel) > 0 {
-		camel = strings.ToLower(camel[:1]) + camel[1:]
-	}
-	return camel
+	return out
 }
 
-// singularize converts plural nouns to singular form with common rules.
-// It’s not perfect like inflection libraries, but good enough for DB tables.
-func singularize(word string) string {
-	word = strings.ToLower(word)
-
-	// Handle common special cases
-	irregulars := map[string]string{
-		"people":   "person",
-		"men":      "man",
-		"women":    "woman",
-		"children": "child",
-		"teeth":    "tooth",
-		"feet":     "foot",
-		"geese":    "goose",
-		"mice":     "mouse",
-		"data":     "datum",
-		"indices":  "index",
-		"matrices": "matrix",
-		"vertices": "vertex",
-		"statuses": "status",
-		"courses":  "course",
-		"quizzes":  "quiz",
-	}
-	if val, ok := irregulars[word]; ok {
-		return val
+// docLines splits a table or column comment into the lines of a Go doc comment
+func docLines(comment string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(comment), "\n") {
+		lines = append(lines, strings.TrimSpace(line))
 	}
-
-	// Handle endings
-	switch {
-	case strings.HasSuffix(word, "ies"):
-		// companies -> company
-		return word[:len(word)-3] + "y"
-	case strings.HasSuffix(word, "ves"):
-		// wolves -> wolf, knives -> knife
-		base := word[:len(word)-3]
-		if strings.HasSuffix(base, "f") {
-			return base
-		}
-		return base + "fe"
-	case strings.HasSuffix(word, "xes"),
-		strings.HasSuffix(word, "ches"),
-		strings.HasSuffix(word, "shes"):
-		// boxes -> box, addresses -> address
-		return word[:len(word)-2]
-	case strings.HasSuffix(word, "zes"):
-		// quizzes -> quiz
-		return word[:len(word)-3]
-	case strings.HasSuffix(word, "oes"):
-		// tomatoes -> tomato, heroes -> hero
-		return word[:len(word)-2]
-	case strings.HasSuffix(word, "s") && len(word) > 1:
-		// generic fallback: users -> user, tables -> table
-		return word[:len(word)-1]
-	}
-
-	return word
-}
-
-func goType(col types.Column) string {
-	sqlType := strings.ToUpper(col.Type)
-	nullable := col.Nullable
-	switch {
-	case strings.HasPrefix(sqlType, "UUID"):
-		return "uuid.UUID"
-	case strings.HasPrefix(sqlType, "VARCHAR"), strings.HasPrefix(sqlType, "TEXT"):
-		if nullable {
-			return "sql.NullString"
-		}
-		return "string"
-	case strings.HasPrefix(sqlType, "DATE"), strings.HasPrefix(sqlType, "TIMESTAMPTZ"):
-		if nullable {
-			return "sql.NullTime"
-		}
-		return "time.Time"
-	case strings.HasPrefix(sqlType, "INT"):
-		if nullable {
-			return "sql.NullInt64"
-		}
-		return "int64"
-	case strings.HasPrefix(sqlType, "SERIAL"):
-		if nullable {
-			return "sql.NullInt64"
-		}
-		return "int64"
-	default:
-		return "string"
+	if len(lines) == 1 && lines[0] == "" {
+		return nil
 	}
+	return lines
 }
 
 func isAuditable(name string) bool {
@@ -205,77 +125,69 @@ func includeInCreate(col types.Column) bool {
 	if col.PrimaryKey || lower == "created_at" || lower == "updated_at" || lower == "deleted_at" {
 		return false
 	}
+	// values the database computes are not part of the payload; columns with a
+	// default stay in it as optional fields
+	if col.Generated || col.Identity {
+		return false
+	}
 	return !isSensitive(col.Name)
 }
 
 func includeInUpdate(col types.Column) bool {
 	lower := strings.ToLower(col.Name)
-	if lower == "id" || lower == "created_at" || lower == "updated_at" || lower == "deleted_at" {
+	// the primary key is taken from the route
+	if col.PrimaryKey || lower == "id" || lower == "created_at" || lower == "updated_at" || lower == "deleted_at" {
+		return false
+	}
+	if col.Generated || col.Identity {
 		return false
 	}
 	return !isSensitive(col.Name)
 }
 
-func goResourceType(col types.Column) string {
-	sqlType := strings.ToUpper(col.Type)
-	switch {
-	case strings.HasPrefix(sqlType, "UUID"):
-		return "string"
-	case strings.HasPrefix(sqlType, "VARCHAR"), strings.HasPrefix(sqlType, "TEXT"):
-		return "string"
-	case strings.HasPrefix(sqlType, "DATE"), strings.HasPrefix(sqlType, "TIMESTAMPTZ"), strings.HasPrefix(sqlType, "TIMESTAMP"):
-		return "string"
-	case strings.HasPrefix(sqlType, "INT"), strings.HasPrefix(sqlType, "SERIAL"):
-		return "int64"
-	default:
-		return "string"
+// bindingTag builds the gin validation tag for a request field
+func bindingTag(col types.Column, create bool) string {
+	var rules []string
+	if create && !col.Nullable && col.Default == "" {
+		rules = append(rules, "required")
+	} else {
+		rules = append(rules, "omitempty")
+	}
+	if col.Length > 0 {
+		rules = append(rules, fmt.Sprintf("max=%d", col.Length))
+	}
+	if col.Enum != nil && len(col.Enum.Values) > 0 {
+		// values oneof cannot express are left to the Valid method of the enum
+		if oneOf, ok := enumOneOf(col.Enum); ok {
+			rules = append(rules, oneOf)
+		}
 	}
+	return fmt.Sprintf(`binding:"%s"`, strings.Join(rules, ","))
 }
 
-func goRequestType(col types.Column, required bool) string {
-	typ := "string"
-	sqlType := strings.ToUpper(col.Type)
-	switch {
-	case strings.HasPrefix(sqlType, "UUID"):
-		typ = "string"
-	case strings.HasPrefix(sqlType, "VARCHAR"), strings.HasPrefix(sqlType, "TEXT"):
-		typ = "string"
-	case strings.HasPrefix(sqlType, "DATE"), strings.HasPrefix(sqlType, "TIMESTAMP"), strings.HasPrefix(sqlType, "TIMESTAMPTZ"):
-		typ = "string"
-	case strings.HasPrefix(sqlType, "INT"), strings.HasPrefix(sqlType, "SERIAL"):
-		typ = "int64"
+// gormTag builds the gorm struct tag for an entity field, followed by a space,
+// or returns "" when the column needs no gorm options
+func gormTag(col types.Column) string {
+	var opts []string
+	if field := naming.Pascal(col.Name); strings.ToLower(strings.Join(naming.Words(field), "_")) != col.Name {
+		// GORM derives the column from the field name, which does not lead back to this one
+		opts = append(opts, "column:"+col.Name)
 	}
-	return typ
-}
-
-func mapFromEntity(col types.Column) string {
-	pascal := toPascalCase(col.Name)
-	sqlType := strings.ToUpper(col.Type)
-	lowerName := strings.ToLower(col.Name)
-
-	switch {
-	case strings.HasPrefix(sqlType, "UUID"):
-		if !col.Nullable || col.PrimaryKey {
-			return "e." + pascal + ".String()"
-		}
-		return "e." + pascal + ".String"
-
-	case strings.HasPrefix(sqlType, "VARCHAR"), strings.HasPrefix(sqlType, "TEXT"):
-		if !col.Nullable {
-			return "e." + pascal
-		}
-		return "e." + pascal + ".String"
-
-	case strings.HasPrefix(sqlType, "DATE"), strings.HasPrefix(sqlType, "TIMESTAMPTZ"), strings.HasPrefix(sqlType, "TIMESTAMP"):
-		if lowerName == "created_at" || lowerName == "updated_at" || !col.Nullable {
-			return "e." + pascal + ".Format(constant.DefaultTimeFormat)"
-		}
-		return "e." + pascal + ".Time.Format(constant.DefaultTimeFormat)"
-
-	case strings.HasPrefix(sqlType, "INT"), strings.HasPrefix(sqlType, "SERIAL"):
-		return "e." + pascal + ".Int64"
-
-	default:
-		return "e." + pascal + ".String"
+	if col.PrimaryKey && !strings.EqualFold(col.Name, "id") {
+		// GORM only assumes a primary key for the ID field
+		opts = append(opts, "primaryKey")
 	}
+	switch {
+	case col.Generated:
+		// generated columns are read-only
+		opts = append(opts, "->")
+	case col.Identity && !col.PrimaryKey:
+		opts = append(opts, "autoIncrement")
+	case col.Default != "" && !strings.ContainsAny(col.Default, "\"`;"):
+		opts = append(opts, "default:"+col.Default)
+	}
+	if len(opts) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(`gorm:"%s" `, strings.Join(opts, ";"))
 }
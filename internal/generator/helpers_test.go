@@ -0,0 +1,42 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+func TestCreateRequestColumns(t *testing.T) {
+	status := &types.Enum{Name: "status", Values: []string{"active", "suspended"}}
+
+	tests := []struct {
+		name    string
+		col     types.Column
+		include bool
+		binding string
+	}{
+		{"required", types.Column{Name: "email", Type: "TEXT"}, true, `binding:"required"`},
+		{"nullable", types.Column{Name: "bio", Type: "TEXT", Nullable: true}, true, `binding:"omitempty"`},
+		{"enum default", types.Column{Name: "status", Type: "STATUS", Default: "'active'", Enum: status}, true, `binding:"omitempty,oneof=active suspended"`},
+		{"boolean default", types.Column{Name: "is_enabled", Type: "BOOLEAN", Default: "true"}, true, `binding:"omitempty"`},
+		{"identity", types.Column{Name: "seq", Type: "BIGINT", Identity: true}, false, ""},
+		{"generated", types.Column{Name: "search", Type: "TSVECTOR", Generated: true}, false, ""},
+		{"primary key", types.Column{Name: "id", Type: "UUID", PrimaryKey: true, Default: "gen_random_uuid()"}, false, ""},
+		{"audit", types.Column{Name: "created_at", Type: "TIMESTAMPTZ", Default: "now()"}, false, ""},
+		{"audit user", types.Column{Name: "created_by", Type: "UUID"}, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := includeInCreate(tt.col); got != tt.include {
+				t.Fatalf("includeInCreate() = %v, want %v", got, tt.include)
+			}
+			if !tt.include {
+				return
+			}
+			if got := bindingTag(tt.col, true); got != tt.binding {
+				t.Errorf("bindingTag() = %s, want %s", got, tt.binding)
+			}
+		})
+	}
+}
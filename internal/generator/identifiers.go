@@ -0,0 +1,101 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+
+	"github.com/rifqiakrm/starter-cli/internal/naming"
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// reservedNames are the predeclared identifiers of Go and the package and
+// variable names the generated code declares itself. Parameters and variables
+// derived from the schema must not shadow them.
+var reservedNames = map[string]bool{
+	// predeclared identifiers
+	"any": true, "append": true, "bool": true, "byte": true, "cap": true, "clear": true, "close": true,
+	"comparable": true, "complex": true, "complex64": true, "complex128": true, "copy": true, "delete": true,
+	"error": true, "false": true, "float32": true, "float64": true, "imag": true, "int": true, "int8": true,
+	"int16": true, "int32": true, "int64": true, "iota": true, "len": true, "make": true, "max": true,
+	"min": true, "new": true, "nil": true, "panic": true, "print": true, "println": true, "real": true,
+	"recover": true, "rune": true, "string": true, "true": true, "uint": true, "uint8": true, "uint16": true,
+	"uint32": true, "uint64": true, "uintptr": true,
+
+	// packages imported by the templates
+	"context": true, "copier": true, "datatypes": true, "decimal": true, "entity": true, "errors": true,
+	"fmt": true, "gin": true, "gorm": true, "http": true, "interfaces": true, "json": true, "net": true,
+	"pq": true, "resource": true, "sql": true, "strconv": true, "time": true, "ulid": true, "uuid": true,
+	"commonCache": true, "commonEntity": true, "commonResource": true, "constant": true, "middleware": true,
+
+	// receivers, parameters and locals of the templates
+	"c": true, "ctx": true, "e": true, "h": true, "r": true, "svc": true, "err": true, "ok": true, "req": true,
+	"res": true, "result": true, "records": true, "list": true, "old": true, "updated": true, "meta": true,
+	"found": true, "query": true, "src": true, "cacheKey": true, "params": true, "limit": true, "offset": true,
+	"includeDeleted": true, "deletedBy": true, "executorID": true, "v": true,
+}
+
+// localName returns a parameter or variable name that is safe to declare in
+// the generated code. Go keywords and reserved names get a Value suffix, so a
+// type column becomes typeValue.
+func localName(name string) string {
+	if token.IsKeyword(name) || reservedNames[name] {
+		return name + "Value"
+	}
+	return name
+}
+
+// identifierError reports schema names that do not make distinct Go identifiers
+type identifierError struct {
+	table    string
+	problems []string
+}
+
+func (e *identifierError) Error() string {
+	return fmt.Sprintf("identifier conflicts in %s:\n  - %s", e.table, strings.Join(e.problems, "\n  - "))
+}
+
+// checkIdentifiers validates the Go identifiers derived from a table against
+// each other and against the other types of its entity package. Keywords and
+// reserved names are escaped where they are used, conflicts are reported.
+func (g *Generator) checkIdentifiers(cat *types.Catalog, tbl *types.Table) error {
+	var problems []string
+
+	fields := map[string]string{"TableName": "the TableName method"}
+	for _, col := range tbl.Columns {
+		if !tbl.IsView && isAuditable(col.Name) {
+			// embedded from commonEntity.Auditable
+			continue
+		}
+		field := naming.Pascal(col.Name)
+		if !token.IsIdentifier(field) {
+			problems = append(problems, fmt.Sprintf("column %q does not make a Go identifier", col.Name))
+			continue
+		}
+		if other, ok := fields[field]; ok {
+			problems = append(problems, fmt.Sprintf("column %s clashes with %s as the field %s.%s", col.Name, other, tbl.NameUpper, field))
+			continue
+		}
+		fields[field] = "column " + col.Name
+	}
+
+	schema := strings.ToLower(tbl.Schema)
+	for _, other := range cat.Tables {
+		if other == tbl || other.NameUpper != tbl.NameUpper || (other.Schema != "" && strings.ToLower(other.Schema) != schema) {
+			continue
+		}
+		problems = append(problems, fmt.Sprintf("tables %s and %s both become the entity %s in package %s/entity; rename one of them",
+			tbl.Name, other.Name, tbl.NameUpper, tbl.Schema))
+	}
+	for _, enum := range cat.Enums {
+		if (enum.Schema == "" || strings.EqualFold(enum.Schema, tbl.Schema)) && enumTypeName(enum) == tbl.NameUpper {
+			problems = append(problems, fmt.Sprintf("enum %s and table %s both become the type %s in package %s/entity",
+				enum.Name, tbl.Name, tbl.NameUpper, tbl.Schema))
+		}
+	}
+
+	if len(problems) > 0 {
+		return &identifierError{table: tbl.Schema + "." + tbl.Name, problems: problems}
+	}
+	return nil
+}
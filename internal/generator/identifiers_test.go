@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/rifqiakrm/starter-cli/internal/config"
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+func TestLocalName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"email", "email"},
+		{"type", "typeValue"},
+		{"func", "funcValue"},
+		{"len", "lenValue"},
+		{"uuid", "uuidValue"},
+		{"ctx", "ctxValue"},
+		{"userID", "userID"},
+	}
+
+	for _, tt := range tests {
+		if got := localName(tt.name); got != tt.want {
+			t.Errorf("localName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCheckIdentifiers(t *testing.T) {
+	users := &types.Table{Schema: "auth", Name: "users", NameUpper: "User", Columns: []types.Column{{Name: "id"}, {Name: "email"}}}
+
+	tests := []struct {
+		name  string
+		table *types.Table
+		cat   *types.Catalog
+		want  []string
+	}{
+		{
+			name:  "distinct names",
+			table: users,
+			cat:   &types.Catalog{Tables: []*types.Table{users}},
+		},
+		{
+			name: "columns making the same field",
+			table: &types.Table{Schema: "auth", Name: "users", NameUpper: "User", Columns: []types.Column{
+				{Name: "user_id"}, {Name: "userId"}, {Name: "table_name"}, {Name: "created_at"},
+			}},
+			want: []string{
+				"column userId clashes with column user_id as the field User.UserID",
+				"column table_name clashes with the TableName method as the field User.TableName",
+			},
+		},
+		{
+			name:  "columns without an identifier",
+			table: &types.Table{Schema: "auth", Name: "users", NameUpper: "User", Columns: []types.Column{{Name: "$"}}},
+			want:  []string{`column "$" does not make a Go identifier`},
+		},
+		{
+			name:  "tables and enums making the same type",
+			table: users,
+			cat: &types.Catalog{
+				Tables: []*types.Table{users, {Schema: "auth", Name: "user", NameUpper: "User"}, {Schema: "billing", Name: "users", NameUpper: "User"}},
+				Enums:  []*types.Enum{{Schema: "auth", Name: "user"}},
+			},
+			want: []string{
+				"tables users and user both become the entity User in package auth/entity; rename one of them",
+				"enum user and table users both become the type User in package auth/entity",
+			},
+		},
+	}
+
+	g := NewGenerator(&config.Config{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cat := tt.cat
+			if cat == nil {
+				cat = &types.Catalog{Tables: []*types.Table{tt.table}}
+			}
+
+			err := g.checkIdentifiers(cat, tt.table)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("checkIdentifiers() error = %v", err)
+				}
+				return
+			}
+			var idErr *identifierError
+			if !errors.As(err, &idErr) {
+				t.Fatalf("checkIdentifiers() error = %v, want an identifierError", err)
+			}
+			if !reflect.DeepEqual(idErr.problems, tt.want) {
+				t.Errorf("problems = %q, want %q", idErr.problems, tt.want)
+			}
+		})
+	}
+}
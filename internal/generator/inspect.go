@@ -0,0 +1,173 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// ShowTable prints the parsed shape of schema.table together with the Go types
+// each column maps to in the entity, the resource and the requests
+func (g *Generator) ShowTable(schema, table, migrationsPath string, w io.Writer) error {
+	tbl, err := g.loadTable(schema, table, migrationsPath)
+	if err != nil {
+		return err
+	}
+
+	kind := "table"
+	if tbl.IsView {
+		kind = "view"
+	}
+	fmt.Fprintf(w, "\n%s.%s (%s, entity %s)\n", tbl.Schema, tbl.Name, kind, tbl.NameUpper)
+	fmt.Fprintf(w, "  source: %s\n", tbl.Source)
+	for _, line := range docLines(tbl.Comment) {
+		fmt.Fprintf(w, "  comment: %s\n", line)
+	}
+
+	m := g.mapper()
+	fmt.Fprintln(w)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "  COLUMN\tSQL TYPE\tNULL\tATTRIBUTES\tGO TYPE\tRESOURCE\tCREATE\tUPDATE")
+	for _, col := range tbl.Columns {
+		nullable := "no"
+		if col.Nullable {
+			nullable = "yes"
+		}
+
+		resource, create, update := "-", "-", "-"
+		if !isSensitive(col.Name) {
+			resource = m.goResourceType(col)
+		}
+		if !tbl.IsView && includeInCreate(col) {
+			create = m.goRequestType(col, true)
+		}
+		if !tbl.IsView && includeInUpdate(col) {
+			update = m.goRequestType(col, false)
+		}
+
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			col.Name, col.Type, nullable, columnAttributes(col), m.goType(col), resource, create, update)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(tbl.Indexes) > 0 {
+		fmt.Fprintln(w, "\n  indexes:")
+		for _, idx := range tbl.Indexes {
+			unique := ""
+			if idx.Unique {
+				unique = "UNIQUE "
+			}
+			fmt.Fprintf(w, "    %s%s (%s)\n", unique, nameOrDash(idx.Name), strings.Join(idx.Columns, ", "))
+		}
+	}
+	if len(tbl.ForeignKeys) > 0 {
+		fmt.Fprintln(w, "\n  foreign keys:")
+		for _, fk := range tbl.ForeignKeys {
+			ref := fk.RefTable
+			if fk.RefSchema != "" {
+				ref = fk.RefSchema + "." + ref
+			}
+			fmt.Fprintf(w, "    %s (%s) -> %s(%s)", nameOrDash(fk.Name), strings.Join(fk.Columns, ", "), ref, strings.Join(fk.RefColumns, ", "))
+			if fk.OnDelete != "" {
+				fmt.Fprintf(w, " ON DELETE %s", fk.OnDelete)
+			}
+			if fk.OnUpdate != "" {
+				fmt.Fprintf(w, " ON UPDATE %s", fk.OnUpdate)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+	if len(tbl.Relations) > 0 {
+		fmt.Fprintln(w, "\n  relations:")
+		for _, rel := range tbl.Relations {
+			fmt.Fprintf(w, "    %s %s %s (foreignKey:%s;references:%s)\n", rel.Kind, rel.FieldName, rel.Entity, rel.ForeignKey, rel.References)
+		}
+	}
+	if finders := g.finders(tbl); len(finders) > 0 {
+		fmt.Fprintln(w, "\n  lookups:")
+		for _, f := range finders {
+			fmt.Fprintf(w, "    FindBy%s(%s)\n", f.Name, f.Params())
+		}
+	}
+	return nil
+}
+
+// ExportSchema writes the whole parsed catalog as JSON or YAML. Relations are
+// resolved for every table, as they are when generating.
+func (g *Generator) ExportSchema(migrationsPath, format string, w io.Writer) error {
+	cat, err := g.loadCatalog(migrationsPath)
+	if err != nil {
+		return fmt.Errorf("schema error: %v", err)
+	}
+	for _, tbl := range cat.Tables {
+		tbl.Relations = buildRelations(cat, tbl)
+	}
+
+	switch strings.ToLower(format) {
+	case "json", "":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(cat)
+	case "yaml", "yml":
+		data, err := yaml.Marshal(cat)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+	return fmt.Errorf("unknown export format %q (supported: json, yaml)", format)
+}
+
+// columnAttributes summarizes the constraints of a column, e.g. "PK, identity"
+func columnAttributes(col types.Column) string {
+	var attrs []string
+	if col.PrimaryKey {
+		attrs = append(attrs, "PK")
+	} else if col.Unique {
+		attrs = append(attrs, "unique")
+	}
+	if col.Identity {
+		attrs = append(attrs, "identity")
+	}
+	if col.Generated {
+		attrs = append(attrs, "generated")
+	}
+	if col.Unsigned {
+		attrs = append(attrs, "unsigned")
+	}
+	if col.Length > 0 {
+		attrs = append(attrs, fmt.Sprintf("max %d", col.Length))
+	}
+	if col.Precision > 0 {
+		attrs = append(attrs, fmt.Sprintf("precision %d,%d", col.Precision, col.Scale))
+	}
+	if col.Enum != nil {
+		attrs = append(attrs, "enum "+strings.Join(col.Enum.Values, "|"))
+	}
+	if col.Default != "" {
+		attrs = append(attrs, "default "+col.Default)
+	}
+	if col.Check != "" {
+		attrs = append(attrs, "check "+col.Check)
+	}
+	if len(attrs) == 0 {
+		return "-"
+	}
+	return strings.Join(attrs, ", ")
+}
+
+func nameOrDash(name string) string {
+	if name == "" {
+		return "-"
+	}
+	return name
+}
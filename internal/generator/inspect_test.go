@@ -0,0 +1,196 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/rifqiakrm/starter-cli/internal/config"
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+const inspectMigration = `
+CREATE TYPE user_status AS ENUM ('active', 'blocked');
+CREATE TABLE users (
+    id UUID PRIMARY KEY,
+    org_unit_id UUID NOT NULL,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    password TEXT NOT NULL,
+    status user_status NOT NULL DEFAULT 'active',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+COMMENT ON TABLE users IS 'Accounts of the users';
+CREATE TABLE orders (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    total NUMERIC(12,2)
+);
+CREATE INDEX orders_user_id_idx ON orders (user_id);
+`
+
+// inspectGenerator returns a generator reading the migrations of the inspect tests
+func inspectGenerator(t *testing.T) (*Generator, string) {
+	t.Helper()
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "000001_init.up.sql"), inspectMigration)
+	return NewGenerator(&config.Config{Dialect: types.DialectPostgres, NullableStyle: types.NullableSQLNull}), dir
+}
+
+func TestShowTable(t *testing.T) {
+	tests := []struct {
+		table string
+		want  string
+	}{
+		{
+			table: "users",
+			want: `
+public.users (table, entity User)
+  source: {dir}/000001_init.up.sql
+  comment: Accounts of the users
+
+  COLUMN       SQL TYPE      NULL  ATTRIBUTES                             GO TYPE     RESOURCE  CREATE  UPDATE
+  id           UUID          no    PK                                     uuid.UUID   string    -       -
+  org_unit_id  UUID          no    -                                      uuid.UUID   string    string  string
+  email        VARCHAR(255)  no    unique, max 255                        string      string    string  string
+  password     TEXT          no    -                                      string      -         -       -
+  status       USER_STATUS   no    enum active|blocked, default 'active'  UserStatus  string    string  string
+  created_at   TIMESTAMPTZ   no    default now()                          time.Time   string    -       -
+
+  indexes:
+    UNIQUE users_email_key (email)
+
+  relations:
+    has_many Orders Order (foreignKey:UserID;references:ID)
+
+  lookups:
+    FindByEmail(email string)
+`,
+		},
+		{
+			table: "orders",
+			want: `
+public.orders (table, entity Order)
+  source: {dir}/000001_init.up.sql
+
+  COLUMN   SQL TYPE       NULL  ATTRIBUTES      GO TYPE              RESOURCE  CREATE           UPDATE
+  id       BIGSERIAL      no    PK, identity    int64                int64     -                -
+  user_id  UUID           no    -               uuid.UUID            string    string           string
+  total    NUMERIC(12,2)  yes   precision 12,2  decimal.NullDecimal  string    decimal.Decimal  decimal.Decimal
+
+  indexes:
+    orders_user_id_idx (user_id)
+
+  foreign keys:
+    - (user_id) -> users(id) ON DELETE CASCADE
+
+  relations:
+    belongs_to User User (foreignKey:UserID;references:ID)
+
+  lookups:
+    FindByUserID(userID uuid.UUID)
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.table, func(t *testing.T) {
+			g, dir := inspectGenerator(t)
+			var out bytes.Buffer
+			if err := g.ShowTable("public", tt.table, dir, &out); err != nil {
+				t.Fatalf("ShowTable() error = %v", err)
+			}
+			if want := strings.ReplaceAll(tt.want, "{dir}", dir); out.String() != want {
+				t.Errorf("ShowTable() =\n%s\nwant\n%s", out.String(), want)
+			}
+		})
+	}
+
+	g, dir := inspectGenerator(t)
+	if err := g.ShowTable("public", "missing", dir, &bytes.Buffer{}); err == nil {
+		t.Error("ShowTable() of a missing table succeeded")
+	}
+}
+
+func TestExportSchema(t *testing.T) {
+	// exportedTable is the part of an exported table the test checks
+	type exportedTable struct {
+		Name      string `json:"name" yaml:"name"`
+		NameUpper string `json:"name_upper" yaml:"name_upper"`
+		Columns   []struct {
+			Name       string `json:"name" yaml:"name"`
+			Type       string `json:"type" yaml:"type"`
+			Nullable   bool   `json:"nullable" yaml:"nullable"`
+			PrimaryKey bool   `json:"primary_key" yaml:"primary_key"`
+			Enum       *struct {
+				Name   string   `json:"name" yaml:"name"`
+				Values []string `json:"values" yaml:"values"`
+			} `json:"enum" yaml:"enum"`
+		} `json:"columns" yaml:"columns"`
+		ForeignKeys []types.ForeignKey `json:"foreign_keys" yaml:"foreign_keys"`
+		Relations   []types.Relation   `json:"relations" yaml:"relations"`
+	}
+	type exportedCatalog struct {
+		Tables []exportedTable `json:"tables" yaml:"tables"`
+	}
+
+	for _, format := range []string{"json", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			g, dir := inspectGenerator(t)
+			var out bytes.Buffer
+			if err := g.ExportSchema(dir, format, &out); err != nil {
+				t.Fatalf("ExportSchema() error = %v", err)
+			}
+
+			var cat exportedCatalog
+			var err error
+			if format == "json" {
+				err = json.Unmarshal(out.Bytes(), &cat)
+			} else {
+				err = yaml.Unmarshal(out.Bytes(), &cat)
+			}
+			if err != nil {
+				t.Fatalf("exported %s does not decode: %v\n%s", format, err, out.String())
+			}
+
+			if len(cat.Tables) != 2 || cat.Tables[0].Name != "users" || cat.Tables[1].Name != "orders" {
+				t.Fatalf("exported tables = %+v, want users and orders", cat.Tables)
+			}
+			users, orders := cat.Tables[0], cat.Tables[1]
+			if users.NameUpper != "User" || len(users.Columns) != 6 {
+				t.Errorf("users = %s with %d columns, want User with 6", users.NameUpper, len(users.Columns))
+			}
+			if id := users.Columns[0]; id.Name != "id" || id.Type != "UUID" || !id.PrimaryKey || id.Nullable {
+				t.Errorf("users.id = %+v, want a UUID primary key", id)
+			}
+			if status := users.Columns[4]; status.Enum == nil || status.Enum.Name != "user_status" ||
+				!reflect.DeepEqual(status.Enum.Values, []string{"active", "blocked"}) {
+				t.Errorf("users.status enum = %+v, want user_status of active and blocked", status.Enum)
+			}
+
+			wantFK := []types.ForeignKey{{Columns: []string{"user_id"}, RefTable: "users", RefColumns: []string{"id"}, OnDelete: "CASCADE"}}
+			if !reflect.DeepEqual(orders.ForeignKeys, wantFK) {
+				t.Errorf("orders foreign keys = %+v, want %+v", orders.ForeignKeys, wantFK)
+			}
+			// relations are resolved for every table, not only the inspected one
+			wantUsers := []types.Relation{{Kind: "has_many", FieldName: "Orders", Entity: "Order", ForeignKey: "UserID", References: "ID", JSONName: "orders"}}
+			if !reflect.DeepEqual(users.Relations, wantUsers) {
+				t.Errorf("users relations = %+v, want %+v", users.Relations, wantUsers)
+			}
+			wantOrders := []types.Relation{{Kind: "belongs_to", FieldName: "User", Entity: "User", ForeignKey: "UserID", References: "ID",
+				Constraint: "OnDelete:CASCADE", JSONName: "user"}}
+			if !reflect.DeepEqual(orders.Relations, wantOrders) {
+				t.Errorf("orders relations = %+v, want %+v", orders.Relations, wantOrders)
+			}
+		})
+	}
+
+	g, dir := inspectGenerator(t)
+	if err := g.ExportSchema(dir, "xml", &bytes.Buffer{}); err == nil || !strings.Contains(err.Error(), `unknown export format "xml"`) {
+		t.Errorf("ExportSchema(xml) error = %v, want an unknown format error", err)
+	}
+}
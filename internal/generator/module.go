@@ -7,51 +7,106 @@ import (
 )
 
 // GenerateModule generates module components based on the specified parts
-func (g *Generator) GenerateModule(schema, entity, version string, parts []types.ModulePart, outputDir string) error {
+func (g *Generator) GenerateModule(schema, entity, version, migrationsPath string, parts []types.ModulePart, outputDir string) error {
 	fmt.Printf("🚀 Generating module components for %s...\n", entity)
 
+	tbl, err := g.loadModuleTable(schema, entity, migrationsPath)
+	if err != nil {
+		return err
+	}
+	files, err := g.generateModuleFiles(schema, entity, version, tbl, parts, outputDir)
+	if err != nil {
+		return err
+	}
+	if err := g.writeFiles(files); err != nil {
+		return err
+	}
+
+	g.updateModuleCacheKeys(schema, entity, version, parts, tbl)
+	return nil
+}
+
+// generateModuleFiles renders the handler, service and repository files of the specified parts
+func (g *Generator) generateModuleFiles(schema, entity, version string, tbl *types.Table, parts []types.ModulePart, outputDir string) ([]outputFile, error) {
+	var files []outputFile
 	for _, part := range parts {
+		var partFiles []outputFile
+		var err error
 		switch part.Component {
 		case "handler":
-			if err := g.generateHandlers(schema, entity, version, part.Action, outputDir); err != nil {
-				return fmt.Errorf("handler generation failed: %v", err)
+			if partFiles, err = g.generateHandlers(schema, entity, version, part.Action, outputDir, tbl); err != nil {
+				return nil, fmt.Errorf("handler generation failed: %v", err)
 			}
 		case "service":
-			if err := g.generateServices(schema, entity, version, part.Action, outputDir); err != nil {
-				return fmt.Errorf("service generation failed: %v", err)
+			if partFiles, err = g.generateServices(schema, entity, version, part.Action, outputDir, tbl); err != nil {
+				return nil, fmt.Errorf("service generation failed: %v", err)
 			}
 		case "repository":
-			if err := g.generateRepositories(schema, entity, version, part.Action, outputDir); err != nil {
-				return fmt.Errorf("repository generation failed: %v", err)
+			if partFiles, err = g.generateRepositories(schema, entity, version, part.Action, outputDir, tbl); err != nil {
+				return nil, fmt.Errorf("repository generation failed: %v", err)
 			}
 		default:
-			return fmt.Errorf("unknown module component: %s", part.Component)
+			return nil, fmt.Errorf("unknown module component: %s", part.Component)
 		}
+		files = append(files, partFiles...)
 	}
 
-	return nil
+	return files, nil
+}
+
+// updateModuleCacheKeys adds the cache keys of the finder repository, when
+// one of the parts generates it, to the project's cache file
+func (g *Generator) updateModuleCacheKeys(schema, entity, version string, parts []types.ModulePart, tbl *types.Table) {
+	for _, part := range parts {
+		if part.Component != "repository" || !contains(getTableActions(part.Action, "repository", tbl), "finder") {
+			continue
+		}
+		if err := g.generateCacheKeysForEntity(schema, entity, g.createTemplateData(schema, entity, version, tbl)); err != nil {
+			fmt.Printf("⚠️  Cache keys generation skipped: %v\n", err)
+			// Don't fail the whole process if cache generation fails
+		}
+		return
+	}
 }
 
-// GenerateAll generates entity, resource, and modules in one command
+// GenerateAll generates entity, resource, and modules in one command. The
+// schema is read once for all of them, and every file is rendered before the
+// first one is written, so existing files are reported for the whole stack at once.
 func (g *Generator) GenerateAll(schema, table, entity, version, migrationsPath, entityOut, resourceOut, moduleOut string, parts []types.ModulePart) error {
 	fmt.Printf("🎯 Generating complete stack for %s.%s...\n", schema, table)
 
+	tbl, err := g.loadTable(schema, table, migrationsPath)
+	if err != nil {
+		return err
+	}
+
 	// Generate entity
-	if err := g.GenerateEntity(schema, table, migrationsPath, entityOut); err != nil {
+	files, err := g.generateEntityFiles(schema, tbl, entityOut)
+	if err != nil {
 		return fmt.Errorf("entity generation failed: %v", err)
 	}
 
 	// Generate resource
-	if err := g.GenerateResource(schema, table, migrationsPath, resourceOut); err != nil {
+	resource, err := g.generateResourceFile(schema, tbl, resourceOut)
+	if err != nil {
 		return fmt.Errorf("resource generation failed: %v", err)
 	}
+	files = append(files, resource)
 
 	// Generate modules
 	if len(parts) > 0 {
-		if err := g.GenerateModule(schema, entity, version, parts, moduleOut); err != nil {
+		fmt.Printf("🚀 Generating module components for %s...\n", entity)
+		moduleFiles, err := g.generateModuleFiles(schema, entity, version, tbl, parts, moduleOut)
+		if err != nil {
 			return fmt.Errorf("module generation failed: %v", err)
 		}
+		files = append(files, moduleFiles...)
+	}
+
+	if err := g.writeFiles(files); err != nil {
+		return err
 	}
+	g.updateModuleCacheKeys(schema, entity, version, parts, tbl)
 
 	fmt.Println("✅ Complete stack generated successfully!")
 	return nil
@@ -0,0 +1,80 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// outputFile is a generated file waiting to be written
+type outputFile struct {
+	path  string
+	code  string
+	label string // what the file holds, e.g. entity or handler.creator
+}
+
+// conflictError lists generated files that already exist
+type conflictError struct {
+	paths []string
+}
+
+func (e *conflictError) Error() string {
+	return fmt.Sprintf("%d file(s) already exist, nothing was written (use --force to overwrite them or --skip-existing to keep them):\n  - %s",
+		len(e.paths), strings.Join(e.paths, "\n  - "))
+}
+
+// writeFiles writes the files a command generated once all of them rendered
+func (g *Generator) writeFiles(files []outputFile) error {
+	return writeOutputFiles(files, g.config.Overwrite)
+}
+
+// writeOutputFiles checks for existing files before writing any: by default
+// nothing is written when one of them exists, --force overwrites them and
+// --skip-existing keeps them. Files that already hold the generated code, such
+// as an enum shared by several tables, are no conflict and left untouched.
+func writeOutputFiles(files []outputFile, mode types.OverwriteMode) error {
+	existing := make(map[string]bool)
+	unchanged := make(map[string]bool)
+	var conflicts []string
+	for _, f := range files {
+		content, err := os.ReadFile(f.path)
+		switch {
+		case err != nil || existing[f.path] || unchanged[f.path]:
+			continue
+		case string(content) == f.code:
+			unchanged[f.path] = true
+		default:
+			existing[f.path] = true
+			conflicts = append(conflicts, f.path)
+		}
+	}
+	if len(conflicts) > 0 && mode == types.OverwriteRefuse {
+		return &conflictError{paths: conflicts}
+	}
+
+	for _, f := range files {
+		if unchanged[f.path] {
+			fmt.Printf("✅ Up to date %s: %s\n", f.label, f.path)
+			continue
+		}
+		if existing[f.path] && mode == types.OverwriteSkip {
+			fmt.Printf("⏭️  Kept existing %s: %s\n", f.label, f.path)
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+			return fmt.Errorf("mkdir error: %v", err)
+		}
+		if err := os.WriteFile(f.path, []byte(f.code), 0644); err != nil {
+			return fmt.Errorf("write %s error: %v", f.label, err)
+		}
+		if existing[f.path] {
+			fmt.Printf("✅ Overwrote %s: %s\n", f.label, f.path)
+		} else {
+			fmt.Printf("✅ Generated %s: %s\n", f.label, f.path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+func TestWriteOutputFiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     types.OverwriteMode
+		wantErr  bool
+		existing string // content of the existing file after the write
+		created  bool   // whether the new file was written
+	}{
+		{"refuse", types.OverwriteRefuse, true, "edited by hand", false},
+		{"force", types.OverwriteForce, false, "generated", true},
+		{"skip", types.OverwriteSkip, false, "edited by hand", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			existingPath := filepath.Join(dir, "entity", "user.go")
+			newPath := filepath.Join(dir, "resource", "user.go")
+			sharedPath := filepath.Join(dir, "entity", "status.go")
+			writeFile(t, existingPath, "edited by hand")
+			writeFile(t, sharedPath, "shared")
+
+			err := writeOutputFiles([]outputFile{
+				{path: existingPath, code: "generated", label: "entity"},
+				{path: newPath, code: "generated", label: "resource"},
+				// a file holding the generated code is no conflict
+				{path: sharedPath, code: "shared", label: "enum"},
+			}, tt.mode)
+
+			var conflict *conflictError
+			if tt.wantErr {
+				if !errors.As(err, &conflict) {
+					t.Fatalf("writeOutputFiles() error = %v, want a conflictError", err)
+				}
+				if len(conflict.paths) != 1 || conflict.paths[0] != existingPath {
+					t.Errorf("conflicts = %q, want %q", conflict.paths, existingPath)
+				}
+			} else if err != nil {
+				t.Fatalf("writeOutputFiles() error = %v", err)
+			}
+
+			if got := readFile(t, existingPath); got != tt.existing {
+				t.Errorf("existing file = %q, want %q", got, tt.existing)
+			}
+			if _, err := os.Stat(newPath); (err == nil) != tt.created {
+				t.Errorf("new file written = %v, want %v", err == nil, tt.created)
+			}
+			if got := readFile(t, sharedPath); got != "shared" {
+				t.Errorf("unchanged file = %q, want %q", got, "shared")
+			}
+		})
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"strings"
+
+	"github.com/rifqiakrm/starter-cli/internal/naming"
 )
 
 // generatePermissionConstants generates permission constants for tables
@@ -32,6 +34,10 @@ func (g *Generator) generatePermissionConstants(module string, tables []string)
 	updatedContent := g.insertPermissionConstants(contentStr, module, newPermissions)
 
 	// Write updated content
+	updatedContent, err = g.formatGo(updatedContent)
+	if err != nil {
+		return fmt.Errorf("updated permission file %s: %v", permissionFilePath, err)
+	}
 	if err := os.WriteFile(permissionFilePath, []byte(updatedContent), 0644); err != nil {
 		return fmt.Errorf("write permission file error: %v", err)
 	}
@@ -45,11 +51,11 @@ func (g *Generator) generatePermissionConstantsForModule(module string, tables [
 	var result strings.Builder
 
 	// Start the module permission block
-	result.WriteString(fmt.Sprintf("// %s permissions\n", toPascalCase(tables[0])))
+	result.WriteString(fmt.Sprintf("// %s permissions\n", naming.Pascal(tables[0])))
 	result.WriteString("const (\n")
 
 	for _, entity := range tables {
-		displayName := toPascalCase(entity)
+		displayName := naming.Pascal(entity)
 
 		// Generate permissions for CRUD operations
 		permissions := []struct {
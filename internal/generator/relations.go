@@ -0,0 +1,155 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/rifqiakrm/starter-cli/internal/naming"
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// buildRelations derives GORM belongs_to and has_many associations for a table.
+// Only tables in the same schema are linked, since entities of other schemas
+// live in a different package.
+func buildRelations(cat *types.Catalog, tbl *types.Table) []types.Relation {
+	if tbl.IsView {
+		return nil
+	}
+
+	var relations []types.Relation
+	used := make(map[string]bool)
+	for _, col := range tbl.Columns {
+		used[naming.Pascal(col.Name)] = true
+	}
+
+	add := func(rel types.Relation) {
+		if used[rel.FieldName] {
+			return
+		}
+		used[rel.FieldName] = true
+		relations = append(relations, rel)
+	}
+
+	// belongs_to: foreign keys declared on this table
+	for _, fk := range tbl.ForeignKeys {
+		target := cat.FindTable(fk.RefSchema, fk.RefTable)
+		if target == nil || !sameSchema(tbl, target) || hasAuditableColumn(fk.Columns) {
+			continue
+		}
+
+		fieldName := target.NameUpper
+		if len(fk.Columns) == 1 && strings.HasSuffix(strings.ToLower(fk.Columns[0]), "_id") {
+			fieldName = naming.Pascal(fk.Columns[0][:len(fk.Columns[0])-3])
+		}
+
+		add(types.Relation{
+			Kind:       "belongs_to",
+			FieldName:  fieldName,
+			Entity:     target.NameUpper,
+			ForeignKey: joinPascal(fk.Columns),
+			References: joinPascal(referencedColumns(fk, target)),
+			Constraint: constraintOptions(fk),
+			JSONName:   strings.ToLower(toSnakeCase(fieldName)),
+		})
+	}
+
+	// has_many: foreign keys on other tables pointing at this table
+	for _, child := range cat.Tables {
+		if child.IsView || !sameSchema(tbl, child) {
+			continue
+		}
+
+		var incoming []types.ForeignKey
+		for _, fk := range child.ForeignKeys {
+			target := cat.FindTable(fk.RefSchema, fk.RefTable)
+			if target == tbl && !hasAuditableColumn(fk.Columns) {
+				incoming = append(incoming, fk)
+			}
+		}
+
+		for _, fk := range incoming {
+			fieldName := naming.Pascal(child.Name)
+			// several keys from the same table need distinct field names
+			if len(incoming) > 1 && len(fk.Columns) == 1 {
+				base := strings.TrimSuffix(strings.ToLower(fk.Columns[0]), "_id")
+				fieldName = naming.Pascal(base) + fieldName
+			}
+
+			add(types.Relation{
+				Kind:       "has_many",
+				FieldName:  fieldName,
+				Entity:     child.NameUpper,
+				ForeignKey: joinPascal(fk.Columns),
+				References: joinPascal(referencedColumns(fk, tbl)),
+				JSONName:   strings.ToLower(toSnakeCase(fieldName)),
+			})
+		}
+	}
+
+	return relations
+}
+
+// referencedColumns returns the target columns of a foreign key, defaulting
+// to the primary key of the referenced table
+func referencedColumns(fk types.ForeignKey, target *types.Table) []string {
+	if len(fk.RefColumns) > 0 {
+		return fk.RefColumns
+	}
+	var pk []string
+	for _, col := range target.Columns {
+		if col.PrimaryKey {
+			pk = append(pk, col.Name)
+		}
+	}
+	if len(pk) == 0 {
+		pk = []string{"id"}
+	}
+	return pk
+}
+
+// constraintOptions renders the ON DELETE / ON UPDATE actions as GORM constraint options
+func constraintOptions(fk types.ForeignKey) string {
+	var opts []string
+	if fk.OnUpdate != "" {
+		opts = append(opts, "OnUpdate:"+fk.OnUpdate)
+	}
+	if fk.OnDelete != "" {
+		opts = append(opts, "OnDelete:"+fk.OnDelete)
+	}
+	return strings.Join(opts, ",")
+}
+
+func sameSchema(a, b *types.Table) bool {
+	return a.Schema == "" || b.Schema == "" || strings.EqualFold(a.Schema, b.Schema)
+}
+
+func hasAuditableColumn(columns []string) bool {
+	for _, col := range columns {
+		if isAuditable(col) {
+			return true
+		}
+	}
+	return false
+}
+
+func joinPascal(columns []string) string {
+	parts := make([]string, len(columns))
+	for i, col := range columns {
+		parts[i] = naming.Pascal(col)
+	}
+	return strings.Join(parts, ",")
+}
+
+// toSnakeCase converts PascalCase to snake_case
+func toSnakeCase(s string) string {
+	var sb strings.Builder
+	for i, r := range s {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 && !(s[i-1] >= 'A' && s[i-1] <= 'Z') {
+				sb.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
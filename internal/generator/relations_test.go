@@ -0,0 +1,137 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rifqiakrm/starter-cli/internal/config"
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// relationCatalog links orders twice to users, categories to themselves and
+// invoices to users from another schema
+func relationCatalog() *types.Catalog {
+	users := &types.Table{Schema: "public", Name: "users", NameUpper: "User", NameLower: "user", Columns: []types.Column{
+		{Name: "id", Type: "UUID", PrimaryKey: true},
+		{Name: "email", Type: "TEXT"},
+	}}
+	orders := &types.Table{Schema: "public", Name: "orders", NameUpper: "Order", NameLower: "order", Columns: []types.Column{
+		{Name: "id", Type: "UUID", PrimaryKey: true},
+		{Name: "user_id", Type: "UUID"},
+		{Name: "approver_id", Type: "UUID", Nullable: true},
+		{Name: "created_by", Type: "UUID"},
+	}, ForeignKeys: []types.ForeignKey{
+		{Columns: []string{"user_id"}, RefTable: "users", RefColumns: []string{"id"}, OnDelete: "CASCADE", OnUpdate: "RESTRICT"},
+		{Columns: []string{"approver_id"}, RefTable: "users", OnDelete: "SET NULL"},
+		// audit columns are no association
+		{Columns: []string{"created_by"}, RefTable: "users", RefColumns: []string{"id"}},
+	}}
+	categories := &types.Table{Schema: "public", Name: "categories", NameUpper: "Category", NameLower: "category", Columns: []types.Column{
+		{Name: "id", Type: "UUID", PrimaryKey: true},
+		{Name: "parent_id", Type: "UUID", Nullable: true},
+	}, ForeignKeys: []types.ForeignKey{
+		{Columns: []string{"parent_id"}, RefTable: "categories", RefColumns: []string{"id"}},
+	}}
+	lines := &types.Table{Schema: "public", Name: "order_lines", NameUpper: "OrderLine", NameLower: "orderLine", Columns: []types.Column{
+		{Name: "order_id", Type: "UUID", PrimaryKey: true},
+		{Name: "line_no", Type: "INTEGER", PrimaryKey: true},
+		{Name: "category", Type: "TEXT"},
+		{Name: "category_id", Type: "UUID"},
+	}, ForeignKeys: []types.ForeignKey{
+		{Columns: []string{"order_id"}, RefTable: "orders"},
+		// the Category field is taken by the category column
+		{Columns: []string{"category_id"}, RefTable: "categories"},
+	}}
+	invoices := &types.Table{Schema: "billing", Name: "invoices", NameUpper: "Invoice", NameLower: "invoice", Columns: []types.Column{
+		{Name: "id", Type: "UUID", PrimaryKey: true},
+		{Name: "user_id", Type: "UUID"},
+	}, ForeignKeys: []types.ForeignKey{
+		{Columns: []string{"user_id"}, RefSchema: "public", RefTable: "users"},
+	}}
+	return &types.Catalog{Tables: []*types.Table{users, orders, categories, lines, invoices}}
+}
+
+func TestBuildRelations(t *testing.T) {
+	tests := []struct {
+		table string
+		want  []types.Relation
+	}{
+		{
+			table: "orders",
+			want: []types.Relation{
+				{Kind: "belongs_to", FieldName: "User", Entity: "User", ForeignKey: "UserID", References: "ID",
+					Constraint: "OnUpdate:RESTRICT,OnDelete:CASCADE", JSONName: "user"},
+				{Kind: "belongs_to", FieldName: "Approver", Entity: "User", ForeignKey: "ApproverID", References: "ID",
+					Constraint: "OnDelete:SET NULL", JSONName: "approver"},
+				{Kind: "has_many", FieldName: "OrderLines", Entity: "OrderLine", ForeignKey: "OrderID", References: "ID", JSONName: "order_lines"},
+			},
+		},
+		{
+			// two keys from orders get the name of their column as prefix;
+			// invoices live in another package and are left out
+			table: "users",
+			want: []types.Relation{
+				{Kind: "has_many", FieldName: "UserOrders", Entity: "Order", ForeignKey: "UserID", References: "ID", JSONName: "user_orders"},
+				{Kind: "has_many", FieldName: "ApproverOrders", Entity: "Order", ForeignKey: "ApproverID", References: "ID", JSONName: "approver_orders"},
+			},
+		},
+		{
+			table: "categories",
+			want: []types.Relation{
+				{Kind: "belongs_to", FieldName: "Parent", Entity: "Category", ForeignKey: "ParentID", References: "ID", JSONName: "parent"},
+				{Kind: "has_many", FieldName: "Categories", Entity: "Category", ForeignKey: "ParentID", References: "ID", JSONName: "categories"},
+				{Kind: "has_many", FieldName: "OrderLines", Entity: "OrderLine", ForeignKey: "CategoryID", References: "ID", JSONName: "order_lines"},
+			},
+		},
+		{
+			table: "order_lines",
+			want: []types.Relation{
+				{Kind: "belongs_to", FieldName: "Order", Entity: "Order", ForeignKey: "OrderID", References: "ID", JSONName: "order"},
+			},
+		},
+		{
+			table: "invoices",
+		},
+	}
+
+	cat := relationCatalog()
+	for _, tt := range tests {
+		t.Run(tt.table, func(t *testing.T) {
+			tbl := cat.FindTable("", tt.table)
+			got := buildRelations(cat, tbl)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("buildRelations(%s) =\n%+v\nwant\n%+v", tt.table, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelationTags(t *testing.T) {
+	cat := relationCatalog()
+	tbl := cat.FindTable("", "orders")
+	tbl.Relations = buildRelations(cat, tbl)
+
+	g := NewGenerator(&config.Config{Dialect: types.DialectPostgres, ModulePath: "example.com/app",
+		TemplatePaths: config.TemplatePaths{Entity: "templates/entity/entity.tmpl", Enum: "templates/entity/enum.tmpl"}})
+	files, err := g.generateEntityFiles("public", tbl, t.TempDir())
+	if err != nil {
+		t.Fatalf("generateEntityFiles() error = %v", err)
+	}
+
+	// gofmt aligns the fields, so they are compared word by word
+	var fields []string
+	for _, line := range strings.Split(files[0].code, "\n") {
+		if strings.Contains(line, "foreignKey:") {
+			fields = append(fields, strings.Join(strings.Fields(line), " "))
+		}
+	}
+	want := []string{
+		"User *User `gorm:\"foreignKey:UserID;references:ID;constraint:OnUpdate:RESTRICT,OnDelete:CASCADE\" json:\"user,omitempty\"`",
+		"Approver *User `gorm:\"foreignKey:ApproverID;references:ID;constraint:OnDelete:SET NULL\" json:\"approver,omitempty\"`",
+		"OrderLines []OrderLine `gorm:\"foreignKey:OrderID;references:ID\" json:\"order_lines,omitempty\"`",
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("association fields =\n%s\nwant\n%s", strings.Join(fields, "\n"), strings.Join(want, "\n"))
+	}
+}
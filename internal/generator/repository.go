@@ -2,21 +2,20 @@ package generator
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
 )
 
 // generateRepositories generates repository components
-func (g *Generator) generateRepositories(schema, entity, version, action, outputDir string) error {
-	actions := getActions(action)
-	data := g.createTemplateData(schema, entity, version)
+func (g *Generator) generateRepositories(schema, entity, version, action, outputDir string, tbl *types.Table) ([]outputFile, error) {
+	actions := getTableActions(action, "repository", tbl)
+	data := g.createTemplateData(schema, entity, version, tbl)
 
 	dir := filepath.Join(outputDir, schema, version, "repository")
-	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
-		return err
-	}
 
+	var files []outputFile
 	for _, act := range actions {
 		var templatePath string
 		switch act {
@@ -29,29 +28,17 @@ func (g *Generator) generateRepositories(schema, entity, version, action, output
 		case "deleter":
 			templatePath = g.config.TemplatePaths.RepositoryDeleter
 		default:
-			return fmt.Errorf("unknown repository action: %s", act)
+			return nil, fmt.Errorf("unknown repository action: %s", act)
 		}
 
 		code, err := g.generateFromTemplate("repository_"+act, data, templatePath)
 		if err != nil {
-			return fmt.Errorf("repository %s template error: %v", act, err)
+			return nil, fmt.Errorf("repository %s template error: %v", act, err)
 		}
 
 		filename := filepath.Join(dir, fmt.Sprintf("%s_%s.repository.go", strings.ToLower(entity), act))
-		if err := os.WriteFile(filename, []byte(code), 0644); err != nil {
-			return fmt.Errorf("write repository %s error: %v", act, err)
-		}
-
-		fmt.Printf("✅ Generated repository.%s: %s\n", act, filename)
-	}
-
-	// Generate cache keys when creating finder repositories
-	if contains(actions, "finder") {
-		if err := g.generateCacheKeysForEntity(schema, entity); err != nil {
-			fmt.Printf("⚠️  Cache keys generation skipped: %v\n", err)
-			// Don't fail the whole process if cache generation fails
-		}
+		files = append(files, outputFile{path: filename, code: code, label: "repository." + act})
 	}
 
-	return nil
+	return files, nil
 }
@@ -2,67 +2,68 @@ package generator
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 	"text/template"
 
-	"github.com/rifqiakrm/starter-cli/internal/parser"
+	"github.com/rifqiakrm/starter-cli/internal/inflection"
+	"github.com/rifqiakrm/starter-cli/internal/naming"
+	"github.com/rifqiakrm/starter-cli/internal/types"
 )
 
 // GenerateResource generates resource code from SQL migrations
 func (g *Generator) GenerateResource(schema, table, migrationsPath, outputDir string) error {
-	fmt.Printf("🔍 Finding migration for %s.%s...\n", schema, table)
-
-	// Find SQL migration file
-	sqlFile, err := parser.FindMigration(migrationsPath, schema, table)
+	tbl, err := g.loadTable(schema, table, migrationsPath)
 	if err != nil {
-		return fmt.Errorf("migration not found: %v", err)
+		return err
 	}
-
-	// Parse SQL to get table structure
-	fmt.Printf("📝 Parsing SQL file: %s\n", sqlFile)
-	tbl, err := parser.ParseSQL(sqlFile)
+	file, err := g.generateResourceFile(schema, tbl, outputDir)
 	if err != nil {
-		return fmt.Errorf("parse error: %v", err)
+		return err
 	}
+	return g.writeFiles([]outputFile{file})
+}
 
+// generateResourceFile renders the resource of a table with its create and update requests
+func (g *Generator) generateResourceFile(schema string, tbl *types.Table, outputDir string) (outputFile, error) {
 	// Format output directory (supports %s placeholder for schema)
 	formattedOutputDir := strings.Replace(outputDir, "%s", schema, 1)
 
+	data := TableData{Table: tbl, ModulePath: g.config.ModulePath}
+
 	// Generate main resource with resource-specific functions
 	fmt.Printf("⚡ Generating resource for %s...\n", tbl.Name)
-	resourceCode, err := g.generateResourceTemplate("resource", tbl, g.config.TemplatePaths.Resource)
+	resourceCode, err := g.generateResourceTemplate("resource", data, g.config.TemplatePaths.Resource)
 	if err != nil {
-		return fmt.Errorf("resource template error: %v", err)
+		return outputFile{}, fmt.Errorf("resource template error: %v", err)
 	}
 
-	// Generate create request
-	createRequestCode, err := g.generateResourceTemplate("create_request", tbl, g.config.TemplatePaths.CreateRequest)
-	if err != nil {
-		return fmt.Errorf("create request template error: %v", err)
-	}
+	combinedCode := resourceCode
 
-	// Generate update request
-	updateRequestCode, err := g.generateResourceTemplate("update_request", tbl, g.config.TemplatePaths.UpdateRequest)
-	if err != nil {
-		return fmt.Errorf("update request template error: %v", err)
-	}
+	// Views are read-only and get no create/update requests
+	if !tbl.IsView {
+		// Generate create request
+		createRequestCode, err := g.generateResourceTemplate("create_request", data, g.config.TemplatePaths.CreateRequest)
+		if err != nil {
+			return outputFile{}, fmt.Errorf("create request template error: %v", err)
+		}
+
+		// Generate update request
+		updateRequestCode, err := g.generateResourceTemplate("update_request", data, g.config.TemplatePaths.UpdateRequest)
+		if err != nil {
+			return outputFile{}, fmt.Errorf("update request template error: %v", err)
+		}
 
-	// Create output directory
-	if err := os.MkdirAll(formattedOutputDir, 0755); err != nil {
-		return fmt.Errorf("mkdir error: %v", err)
+		combinedCode += "\n" + createRequestCode + "\n" + updateRequestCode
 	}
 
-	// Write resource file
-	resourceFile := filepath.Join(formattedOutputDir, fmt.Sprintf("%s.resource.go", tbl.NameLower))
-	combinedCode := resourceCode + "\n" + createRequestCode + "\n" + updateRequestCode
-	if err := os.WriteFile(resourceFile, []byte(combinedCode), 0644); err != nil {
-		return fmt.Errorf("write resource error: %v", err)
+	combinedCode, err = g.formatGo(combinedCode)
+	if err != nil {
+		return outputFile{}, fmt.Errorf("resource templates: %v", err)
 	}
 
-	fmt.Printf("✅ Generated resource: %s\n", resourceFile)
-	return nil
+	resourceFile := filepath.Join(formattedOutputDir, fmt.Sprintf("%s.resource.go", tbl.NameLower))
+	return outputFile{path: resourceFile, code: combinedCode, label: "resource"}, nil
 }
 
 // generateResourceTemplate is specifically for resource templates with resource functions
@@ -74,14 +75,21 @@ func (g *Generator) generateResourceTemplate(templateName string, data interface
 
 	// Resource-specific template functions
 	funcMap := template.FuncMap{
-		"ToCamel":         toCamelCase,
-		"ToPascalCase":    toPascalCase,
+		"ToCamel":         naming.Camel,
+		"ToPascalCase":    naming.Pascal,
 		"IsSensitive":     isSensitive,
 		"IncludeInCreate": includeInCreate,
 		"IncludeInUpdate": includeInUpdate,
-		"GoResourceType":  goResourceType,
-		"GoRequestType":   goRequestType,
-		"MapFromEntity":   mapFromEntity,
+		"GoResourceType":  g.mapper().goResourceType,
+		"GoRequestType":   g.mapper().goRequestType,
+		"MapFromEntity":   g.mapper().mapFromEntity,
+		"NullCheck":       g.mapper().nullCheck,
+		"HasNullChecks":   g.mapper().hasNullChecks,
+		"ResourceImports": g.mapper().resourceImports,
+		"BindingTag":      bindingTag,
+		"DocLines":        docLines,
+		"Plural":          inflection.Plural,
+		"Singular":        inflection.Singular,
 	}
 
 	tmpl, err := template.New(templateName).Funcs(funcMap).Parse(tmplContent)
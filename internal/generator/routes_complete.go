@@ -2,53 +2,48 @@ package generator
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 	"text/template"
 
+	"github.com/rifqiakrm/starter-cli/internal/inflection"
+	"github.com/rifqiakrm/starter-cli/internal/naming"
 	"github.com/rifqiakrm/starter-cli/internal/types"
 )
 
 // generateCompleteRoutes generates complete routes file for new module
-func (g *Generator) generateCompleteRoutes(module, version string, tables []string) error {
+func (g *Generator) generateCompleteRoutes(module, version string, tables []string, views map[string]bool, keys map[string]string) (outputFile, error) {
 	fmt.Printf("🛣️  Generating complete routes for %s\n", module)
 
 	// Prepare routes configuration
-	config := g.buildRoutesConfig(module, version, tables)
+	config := g.buildRoutesConfig(module, version, tables, views, keys)
 
 	// Generate routes code
 	routesCode, err := g.generateRoutesCode(config)
 	if err != nil {
-		return fmt.Errorf("routes template error: %v", err)
+		return outputFile{}, fmt.Errorf("routes template error: %v", err)
 	}
 
-	// Create app directory if needed
-	appDir := "app"
-	if err := os.MkdirAll(appDir, 0755); err != nil {
-		return fmt.Errorf("mkdir error: %v", err)
-	}
-
-	// Write routes file
-	routesFile := filepath.Join(appDir, fmt.Sprintf("%s_routes.go", module))
-	if err := os.WriteFile(routesFile, []byte(routesCode), 0644); err != nil {
-		return fmt.Errorf("write routes error: %v", err)
-	}
-
-	fmt.Printf("✅ Generated routes: %s\n", routesFile)
-	return nil
+	routesFile := filepath.Join("app", fmt.Sprintf("%s_routes.go", module))
+	return outputFile{path: routesFile, code: routesCode, label: "routes"}, nil
 }
 
 // buildRoutesConfig creates configuration for routes
-func (g *Generator) buildRoutesConfig(module, version string, tables []string) types.RoutesConfig {
+func (g *Generator) buildRoutesConfig(module, version string, tables []string, views map[string]bool, keys map[string]string) types.RoutesConfig {
 	tableConfigs := make([]types.TableConfig, 0, len(tables))
+	hasWritable := false
 
 	for _, table := range tables {
 		tableConfigs = append(tableConfigs, types.TableConfig{
 			Name:        table,
-			DisplayName: toPascalCase(table),
+			DisplayName: naming.Pascal(table),
 			Module:      module,
+			IsView:      views[table],
+			KeyPath:     keys[table],
 		})
+		if !views[table] {
+			hasWritable = true
+		}
 	}
 
 	return types.RoutesConfig{
@@ -56,9 +51,11 @@ func (g *Generator) buildRoutesConfig(module, version string, tables []string) t
 		Version:       version,
 		Tables:        tableConfigs,
 		RoutePrefix:   fmt.Sprintf("/%s/%s", module, version),
-		ImportPath:    fmt.Sprintf("gin-starter/modules/%s/%s", module, version),
-		HandlerPrefix: toPascalCase(module),
-		HandlerStruct: fmt.Sprintf("%sHTTPHandler", toPascalCase(module)),
+		ModulePath:    g.config.ModulePath,
+		ImportPath:    fmt.Sprintf("%s/modules/%s/%s", g.config.ModulePath, module, version),
+		HandlerPrefix: naming.Pascal(module),
+		HandlerStruct: fmt.Sprintf("%sHTTPHandler", naming.Pascal(module)),
+		HasWritable:   hasWritable,
 	}
 }
 
@@ -77,10 +74,12 @@ func (g *Generator) generateRoutesCode(config types.RoutesConfig) (string, error
 
 	// Create template with functions
 	funcMap := template.FuncMap{
-		"ToCamel":      toCamelCase,
-		"ToPascal":     toPascalCase,
+		"ToCamel":      naming.Camel,
+		"ToPascal":     naming.Pascal,
 		"ToLower":      strings.ToLower,
-		"GetRoutePath": g.getRoutePath,
+		"GetRoutePath": inflection.Plural,
+		"Plural":       inflection.Plural,
+		"Singular":     inflection.Singular,
 	}
 
 	tmpl, err := template.New("routes").Funcs(funcMap).Parse(tmplContent)
@@ -93,14 +92,9 @@ func (g *Generator) generateRoutesCode(config types.RoutesConfig) (string, error
 		return "", fmt.Errorf("execute routes template error: %v", err)
 	}
 
-	return buf.String(), nil
-}
-
-// getRoutePath returns the route path for an table
-func (g *Generator) getRoutePath(tableName string) string {
-	// Convert "user" -> "users", "category" -> "categories"
-	if strings.HasSuffix(tableName, "y") {
-		return strings.TrimSuffix(tableName, "y") + "ies"
+	code, err := g.formatGo(buf.String())
+	if err != nil {
+		return "", fmt.Errorf("routes template: %v", err)
 	}
-	return tableName + "s"
+	return code, nil
 }
@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"os"
 	"strings"
+
+	"github.com/rifqiakrm/starter-cli/internal/inflection"
+	"github.com/rifqiakrm/starter-cli/internal/naming"
 )
 
 // updateRoutesIncremental adds new tables to existing routes
-func (g *Generator) updateRoutesIncremental(module, version string, newTables []string) error {
+func (g *Generator) updateRoutesIncremental(module, version string, newTables []string, views map[string]bool, keys map[string]string) error {
 	fmt.Printf("🛣️  Updating existing routes for %s with new tables: %v\n", module, newTables)
 
 	// Analyze existing routes
@@ -28,16 +31,20 @@ func (g *Generator) updateRoutesIncremental(module, version string, newTables []
 	// Update each of the 4 route methods
 	updatedContent := analysis.Content
 	for method := range analysis.MethodBlocks {
-		updatedContent = g.updateRouteMethod(updatedContent, module, method, tablesToAdd)
+		updatedContent = g.updateRouteMethod(updatedContent, module, method, g.methodTables(method, tablesToAdd, views), keys)
 	}
 
 	// Update the handler struct fields
-	updatedContent = g.updateHandlerStruct(updatedContent, module, tablesToAdd)
+	updatedContent = g.updateHandlerStruct(updatedContent, module, tablesToAdd, views)
 
 	// Update the handler constructor
-	updatedContent = g.updateRouteHandlerConstructor(updatedContent, module, tablesToAdd)
+	updatedContent = g.updateRouteHandlerConstructor(updatedContent, module, tablesToAdd, views)
 
 	// Write updated routes file
+	updatedContent, err = g.formatGo(updatedContent)
+	if err != nil {
+		return fmt.Errorf("updated routes file %s: %v", analysis.FilePath, err)
+	}
 	if err := os.WriteFile(analysis.FilePath, []byte(updatedContent), 0644); err != nil {
 		return fmt.Errorf("write routes error: %v", err)
 	}
@@ -52,8 +59,27 @@ func (g *Generator) updateRoutesIncremental(module, version string, newTables []
 	return nil
 }
 
+// methodTables returns the tables that get routes in a method.
+// Views only have finder routes.
+func (g *Generator) methodTables(method string, tables []string, views map[string]bool) []string {
+	if method == "Finder" {
+		return tables
+	}
+	var out []string
+	for _, entity := range tables {
+		if !views[entity] {
+			out = append(out, entity)
+		}
+	}
+	return out
+}
+
 // updateRouteMethod - simpler approach: insert before v1's closing brace
-func (g *Generator) updateRouteMethod(content, module, method string, tables []string) string {
+func (g *Generator) updateRouteMethod(content, module, method string, tables []string, keys map[string]string) string {
+	if len(tables) == 0 {
+		return content
+	}
+
 	lines := strings.Split(content, "\n")
 
 	for i, line := range lines {
@@ -68,7 +94,7 @@ func (g *Generator) updateRouteMethod(content, module, method string, tables []s
 				if strings.TrimSpace(lines[j]) == "}" &&
 					strings.HasPrefix(lines[j], "\t}") {
 					// This is v1's closing brace - insert routes BEFORE it
-					newRoutes := g.generateEntityRoutes(module, method, tables)
+					newRoutes := g.generateEntityRoutes(module, method, tables, keys)
 
 					updatedLines := make([]string, len(lines)+len(strings.Split(newRoutes, "\n")))
 					copy(updatedLines[:j], lines[:j])
@@ -117,7 +143,7 @@ func (g *Generator) generateHandlerDeclarations(module, method string, tables []
 	var result strings.Builder
 
 	for _, entity := range tables {
-		displayName := toPascalCase(entity)
+		displayName := naming.Pascal(entity)
 		result.WriteString(fmt.Sprintf("\t%sHnd := %shandlerv1.New%s%sHandler(",
 			entity, module, displayName, method))
 
@@ -137,14 +163,15 @@ func (g *Generator) generateHandlerDeclarations(module, method string, tables []
 }
 
 // generateEntityRoutes generates route code for new tables in a method
-func (g *Generator) generateEntityRoutes(module, method string, tables []string) string {
+func (g *Generator) generateEntityRoutes(module, method string, tables []string, keys map[string]string) string {
 	var result strings.Builder
 
 	for _, entity := range tables {
-		displayName := toPascalCase(entity)
-		routePath := g.getRoutePath(entity)
-		groupName := g.getGroupName(entity)
-		pluralDisplayName := g.getPluralDisplayName(entity) // NEW: Get plural for method names
+		displayName := naming.Pascal(entity)
+		routePath := inflection.Plural(entity)
+		groupName := inflection.Plural(entity)
+		pluralDisplayName := inflection.Plural(displayName)
+		keyPath := keys[entity]
 
 		result.WriteString("\n\t\t")
 		result.WriteString(groupName)
@@ -165,7 +192,7 @@ func (g *Generator) generateEntityRoutes(module, method string, tables []string)
 			result.WriteString(pluralDisplayName) // Use plural for method name
 			result.WriteString(")\n\t\t\t")
 			result.WriteString(groupName)
-			result.WriteString(".GET(\"/:id\", ")
+			result.WriteString(".GET(\"" + keyPath + "\", ")
 			result.WriteString(entity)
 			result.WriteString("Hnd.Get")
 			result.WriteString(displayName)
@@ -181,7 +208,7 @@ func (g *Generator) generateEntityRoutes(module, method string, tables []string)
 		case "Updater":
 			result.WriteString("\t\t\t")
 			result.WriteString(groupName)
-			result.WriteString(".PUT(\"/:id\", ")
+			result.WriteString(".PUT(\"" + keyPath + "\", ")
 			result.WriteString(entity)
 			result.WriteString("Hnd.Update")
 			result.WriteString(displayName)
@@ -189,7 +216,7 @@ func (g *Generator) generateEntityRoutes(module, method string, tables []string)
 		case "Deleter":
 			result.WriteString("\t\t\t")
 			result.WriteString(groupName)
-			result.WriteString(".DELETE(\"/:id\", ")
+			result.WriteString(".DELETE(\"" + keyPath + "\", ")
 			result.WriteString(entity)
 			result.WriteString("Hnd.Delete")
 			result.WriteString(displayName)
@@ -202,46 +229,6 @@ func (g *Generator) generateEntityRoutes(module, method string, tables []string)
 	return result.String()
 }
 
-// getPluralDisplayName returns the plural form for method names
-func (g *Generator) getPluralDisplayName(entity string) string {
-	// Handle special cases for method names
-	switch entity {
-	case "category":
-		return "Categories" // "GetAllCategories" not "GetAllCategorys"
-	case "product":
-		return "Products"
-	case "user":
-		return "Users"
-	case "role":
-		return "Roles"
-	case "permission":
-		return "Permissions"
-	case "organization":
-		return "Organizations"
-	case "person":
-		return "People"
-	case "child":
-		return "Children"
-	case "man":
-		return "Men"
-	case "woman":
-		return "Women"
-	default:
-		// Default pluralization rules
-		if strings.HasSuffix(entity, "y") {
-			return strings.TrimSuffix(toPascalCase(entity), "y") + "ies"
-		} else if strings.HasSuffix(entity, "s") ||
-			strings.HasSuffix(entity, "x") ||
-			strings.HasSuffix(entity, "z") ||
-			strings.HasSuffix(entity, "ch") ||
-			strings.HasSuffix(entity, "sh") {
-			return toPascalCase(entity) + "es"
-		} else {
-			return toPascalCase(entity) + "s"
-		}
-	}
-}
-
 // getPermissionAction returns the permission action for a method
 func (g *Generator) getPermissionAction(method string) string {
 	switch method {
@@ -259,7 +246,7 @@ func (g *Generator) getPermissionAction(method string) string {
 }
 
 // updateHandlerStruct adds new entity fields to handler struct
-func (g *Generator) updateHandlerStruct(content, module string, tables []string) string {
+func (g *Generator) updateHandlerStruct(content, module string, tables []string, views map[string]bool) string {
 	lines := strings.Split(content, "\n")
 
 	for i, line := range lines {
@@ -267,7 +254,7 @@ func (g *Generator) updateHandlerStruct(content, module string, tables []string)
 			// Find where to insert new fields (before cloudStorage)
 			for j := i; j < len(lines); j++ {
 				if strings.Contains(lines[j], "cloudStorage") || strings.Contains(lines[j], "cache") {
-					newFields := g.generateHandlerFields(module, tables)
+					newFields := g.generateHandlerFields(module, tables, views)
 
 					updatedLines := make([]string, len(lines)+len(strings.Split(newFields, "\n")))
 					copy(updatedLines[:j], lines[:j])
@@ -285,11 +272,16 @@ func (g *Generator) updateHandlerStruct(content, module string, tables []string)
 }
 
 // generateHandlerFields generates handler struct fields for new tables
-func (g *Generator) generateHandlerFields(module string, tables []string) string {
+func (g *Generator) generateHandlerFields(module string, tables []string, views map[string]bool) string {
 	var result strings.Builder
 
 	for _, entity := range tables {
-		displayName := toPascalCase(entity)
+		displayName := naming.Pascal(entity)
+		if views[entity] {
+			result.WriteString(fmt.Sprintf("\t%sFinder %sservicev1.%sFinderUseCase\n",
+				entity, module, displayName))
+			continue
+		}
 		result.WriteString(fmt.Sprintf("\t%sCreator %sservicev1.%sCreatorUseCase\n",
 			entity, module, displayName))
 		result.WriteString(fmt.Sprintf("\t%sFinder %sservicev1.%sFinderUseCase\n",
@@ -304,7 +296,7 @@ func (g *Generator) generateHandlerFields(module string, tables []string) string
 }
 
 // updateRouteHandlerConstructor adds new entity parameters to handler constructor in routes
-func (g *Generator) updateRouteHandlerConstructor(content, module string, tables []string) string {
+func (g *Generator) updateRouteHandlerConstructor(content, module string, tables []string, views map[string]bool) string {
 	lines := strings.Split(content, "\n")
 
 	for i, line := range lines {
@@ -314,7 +306,7 @@ func (g *Generator) updateRouteHandlerConstructor(content, module string, tables
 			for j := i; j < len(lines); j++ {
 				if strings.Contains(lines[j], "cloudStorage") || strings.Contains(lines[j], "cache") {
 					// Generate new parameters for the constructor
-					newParams := g.generateHandlerConstructorParams(module, tables)
+					newParams := g.generateHandlerConstructorParams(module, tables, views)
 
 					// Insert before cloudStorage/cache
 					updatedLines := make([]string, len(lines)+len(strings.Split(newParams, "\n")))
@@ -325,7 +317,7 @@ func (g *Generator) updateRouteHandlerConstructor(content, module string, tables
 					content = strings.Join(updatedLines, "\n")
 
 					// Now update the constructor body (field assignments)
-					return g.updateHandlerConstructorBody(content, module, tables)
+					return g.updateHandlerConstructorBody(content, module, tables, views)
 				}
 			}
 			break
@@ -336,11 +328,16 @@ func (g *Generator) updateRouteHandlerConstructor(content, module string, tables
 }
 
 // generateHandlerConstructorParams generates constructor parameters for new tables
-func (g *Generator) generateHandlerConstructorParams(module string, tables []string) string {
+func (g *Generator) generateHandlerConstructorParams(module string, tables []string, views map[string]bool) string {
 	var result strings.Builder
 
 	for _, entity := range tables {
-		displayName := toPascalCase(entity)
+		displayName := naming.Pascal(entity)
+		if views[entity] {
+			result.WriteString(fmt.Sprintf("\t%sFinder %sservicev1.%sFinderUseCase,\n",
+				entity, module, displayName))
+			continue
+		}
 		result.WriteString(fmt.Sprintf("\t%sCreator %sservicev1.%sCreatorUseCase,\n",
 			entity, module, displayName))
 		result.WriteString(fmt.Sprintf("\t%sFinder %sservicev1.%sFinderUseCase,\n",
@@ -355,7 +352,7 @@ func (g *Generator) generateHandlerConstructorParams(module string, tables []str
 }
 
 // updateHandlerConstructorBody updates the constructor body with new field assignments
-func (g *Generator) updateHandlerConstructorBody(content, module string, tables []string) string {
+func (g *Generator) updateHandlerConstructorBody(content, module string, tables []string, views map[string]bool) string {
 	lines := strings.Split(content, "\n")
 
 	for i, line := range lines {
@@ -365,7 +362,7 @@ func (g *Generator) updateHandlerConstructorBody(content, module string, tables
 			for j := i; j < len(lines); j++ {
 				if strings.Contains(lines[j], "cloudStorage:") || strings.Contains(lines[j], "cache:") {
 					// Generate new field assignments
-					newAssignments := g.generateHandlerFieldAssignments(tables)
+					newAssignments := g.generateHandlerFieldAssignments(tables, views)
 
 					// Insert before cloudStorage/cache
 					updatedLines := make([]string, len(lines)+len(strings.Split(newAssignments, "\n")))
@@ -384,10 +381,14 @@ func (g *Generator) updateHandlerConstructorBody(content, module string, tables
 }
 
 // generateHandlerFieldAssignments generates field assignments for new tables
-func (g *Generator) generateHandlerFieldAssignments(tables []string) string {
+func (g *Generator) generateHandlerFieldAssignments(tables []string, views map[string]bool) string {
 	var result strings.Builder
 
 	for _, entity := range tables {
+		if views[entity] {
+			result.WriteString(fmt.Sprintf("\t\t%sFinder: %sFinder,\n", entity, entity))
+			continue
+		}
 		result.WriteString(fmt.Sprintf("\t\t%sCreator: %sCreator,\n", entity, entity))
 		result.WriteString(fmt.Sprintf("\t\t%sFinder: %sFinder,\n", entity, entity))
 		result.WriteString(fmt.Sprintf("\t\t%sUpdater: %sUpdater,\n", entity, entity))
@@ -396,43 +397,3 @@ func (g *Generator) generateHandlerFieldAssignments(tables []string) string {
 
 	return result.String()
 }
-
-// getGroupName returns the proper pluralized group name
-func (g *Generator) getGroupName(entity string) string {
-	// Handle special cases
-	switch entity {
-	case "category":
-		return "categories"
-	case "product":
-		return "products"
-	case "user":
-		return "users"
-	case "role":
-		return "roles"
-	case "permission":
-		return "permissions"
-	case "organization":
-		return "organizations"
-	case "person":
-		return "people"
-	case "child":
-		return "children"
-	case "man":
-		return "men"
-	case "woman":
-		return "women"
-	default:
-		// Default pluralization rules
-		if strings.HasSuffix(entity, "y") {
-			return strings.TrimSuffix(entity, "y") + "ies"
-		} else if strings.HasSuffix(entity, "s") ||
-			strings.HasSuffix(entity, "x") ||
-			strings.HasSuffix(entity, "z") ||
-			strings.HasSuffix(entity, "ch") ||
-			strings.HasSuffix(entity, "sh") {
-			return entity + "es"
-		} else {
-			return entity + "s"
-		}
-	}
-}
@@ -0,0 +1,120 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rifqiakrm/starter-cli/internal/inflection"
+	"github.com/rifqiakrm/starter-cli/internal/naming"
+	"github.com/rifqiakrm/starter-cli/internal/parser"
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// loadTable reads the schema source and returns the final shape of schema.table
+// with its relationships to the other tables resolved
+func (g *Generator) loadTable(schema, table, migrationsPath string) (*types.Table, error) {
+	if g.config.SchemaFile != "" {
+		fmt.Printf("🔍 Reading schema file %s for %s.%s...\n", g.config.SchemaFile, schema, table)
+	} else {
+		fmt.Printf("🔍 Replaying migrations in %s for %s.%s...\n", migrationsPath, schema, table)
+	}
+
+	cat, err := g.loadCatalog(migrationsPath)
+	if err != nil {
+		return nil, fmt.Errorf("schema error: %v", err)
+	}
+	g.printWarnings(cat)
+
+	tbl := cat.FindTable(schema, table)
+	if tbl == nil {
+		if g.config.SchemaFile != "" {
+			return nil, fmt.Errorf("table not found: %s.%s is not defined in %s", schema, table, g.config.SchemaFile)
+		}
+		return nil, fmt.Errorf("migration not found: no migration for %s.%s", schema, table)
+	}
+	if tbl.Schema == "" {
+		tbl.Schema = schema
+	}
+	tbl.Relations = buildRelations(cat, tbl)
+	if err := g.checkIdentifiers(cat, tbl); err != nil {
+		return nil, err
+	}
+	g.warnUnknownTypes(tbl)
+
+	fmt.Printf("📝 Resolved %s.%s from %s with %d columns\n", tbl.Schema, tbl.Name, tbl.Source, len(tbl.Columns))
+	return tbl, nil
+}
+
+// loadCatalog reads the configured schema file, or replays the migrations under
+// migrationsPath when no schema file is set
+func (g *Generator) loadCatalog(migrationsPath string) (*types.Catalog, error) {
+	if g.config.SchemaFile != "" {
+		return parser.LoadSchemaFile(g.config.SchemaFile, g.config.Dialect)
+	}
+	return parser.LoadCatalog(migrationsPath, g.config.Dialect, g.migrationReader())
+}
+
+// migrationReader returns the reader for the configured migration layout
+func (g *Generator) migrationReader() parser.MigrationReader {
+	return parser.NewMigrationReader(g.config.MigrationFormat)
+}
+
+// printWarnings prints the clauses the parser ignored, once per run
+func (g *Generator) printWarnings(cat *types.Catalog) {
+	for _, w := range cat.Warnings {
+		msg := w.String()
+		if g.warned[msg] {
+			continue
+		}
+		g.warned[msg] = true
+		fmt.Printf("⚠️  %s\n", msg)
+	}
+}
+
+// warnUnknownTypes warns once per type about columns whose SQL type has no
+// built-in or configured mapping and falls back to string
+func (g *Generator) warnUnknownTypes(tbl *types.Table) {
+	m := g.mapper()
+	for _, col := range tbl.Columns {
+		if m.known(col) {
+			continue
+		}
+		key := "type " + col.Type
+		if g.warned[key] {
+			continue
+		}
+		g.warned[key] = true
+		fmt.Printf("⚠️  unknown SQL type %s of %s.%s is mapped to string; add it to type_mappings\n", col.Type, tbl.Name, col.Name)
+	}
+}
+
+// loadModuleTable resolves the table behind a module. When there is no schema
+// source at all, neither a schema file nor a migrations directory, it falls
+// back to a table with a single UUID primary key so modules can still be
+// generated. Errors reading an existing source are returned.
+func (g *Generator) loadModuleTable(schema, entity, migrationsPath string) (*types.Table, error) {
+	if g.config.SchemaFile != "" || hasMigrations(migrationsPath) {
+		return g.loadTable(schema, entity, migrationsPath)
+	}
+	fmt.Printf("⚠️  Using default table layout for %s.%s: no schema file is set and there is no migrations directory %q\n", schema, entity, migrationsPath)
+
+	return &types.Table{
+		Schema:    schema,
+		Name:      entity,
+		NameUpper: naming.Pascal(inflection.Singular(entity)),
+		NameLower: strings.ToLower(entity),
+		Columns: []types.Column{
+			{Name: "id", Type: "UUID", PrimaryKey: true, Unique: true},
+		},
+	}, nil
+}
+
+// hasMigrations reports whether the migrations directory is set and exists
+func hasMigrations(migrationsPath string) bool {
+	if migrationsPath == "" {
+		return false
+	}
+	_, err := os.Stat(migrationsPath)
+	return !os.IsNotExist(err)
+}
@@ -0,0 +1,55 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rifqiakrm/starter-cli/internal/config"
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+func TestLoadModuleTable(t *testing.T) {
+	tests := []struct {
+		name       string
+		migration  string // content of the only migration, or "" for no migrations directory
+		schemaFile bool   // whether the migration is read as a configured schema file
+		columns    int
+		err        string
+	}{
+		{name: "no migrations directory", columns: 1},
+		{name: "table found", migration: "CREATE TABLE auth.users (id UUID PRIMARY KEY, email TEXT);", columns: 2},
+		{name: "table missing", migration: "CREATE TABLE auth.roles (id UUID PRIMARY KEY);", err: "no migration for auth.users"},
+		{name: "parse error", migration: "CREATE TABLE auth.users (id UUID PRIMARY KEY,", err: "000001_users.up.sql:1:"},
+		{name: "schema file missing the table", migration: "CREATE TABLE auth.roles (id UUID PRIMARY KEY);", schemaFile: true, err: "is not defined in"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations := filepath.Join(t.TempDir(), "migrations")
+			cfg := &config.Config{Dialect: types.DialectPostgres}
+			if tt.migration != "" {
+				path := filepath.Join(migrations, "000001_users.up.sql")
+				writeFile(t, path, tt.migration)
+				if tt.schemaFile {
+					cfg.SchemaFile = path
+					migrations = filepath.Join(migrations, "missing")
+				}
+			}
+
+			tbl, err := NewGenerator(cfg).loadModuleTable("auth", "users", migrations)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("loadModuleTable() error = %v, want %s", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadModuleTable() error = %v", err)
+			}
+			if len(tbl.Columns) != tt.columns {
+				t.Errorf("columns = %+v, want %d", tbl.Columns, tt.columns)
+			}
+		})
+	}
+}
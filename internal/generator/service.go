@@ -2,21 +2,20 @@ package generator
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
 )
 
 // generateServices generates service components
-func (g *Generator) generateServices(schema, entity, version, action, outputDir string) error {
-	actions := getActions(action)
-	data := g.createTemplateData(schema, entity, version)
+func (g *Generator) generateServices(schema, entity, version, action, outputDir string, tbl *types.Table) ([]outputFile, error) {
+	actions := getTableActions(action, "service", tbl)
+	data := g.createTemplateData(schema, entity, version, tbl)
 
 	dir := filepath.Join(outputDir, schema, version, "service")
-	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
-		return err
-	}
 
+	var files []outputFile
 	for _, act := range actions {
 		var templatePath string
 		switch act {
@@ -29,21 +28,17 @@ func (g *Generator) generateServices(schema, entity, version, action, outputDir
 		case "deleter":
 			templatePath = g.config.TemplatePaths.ServiceDeleter
 		default:
-			return fmt.Errorf("unknown service action: %s", act)
+			return nil, fmt.Errorf("unknown service action: %s", act)
 		}
 
 		code, err := g.generateFromTemplate("service_"+act, data, templatePath)
 		if err != nil {
-			return fmt.Errorf("service %s template error: %v", act, err)
+			return nil, fmt.Errorf("service %s template error: %v", act, err)
 		}
 
 		filename := filepath.Join(dir, fmt.Sprintf("%s_%s.service.go", strings.ToLower(entity), act))
-		if err := os.WriteFile(filename, []byte(code), 0644); err != nil {
-			return fmt.Errorf("write service %s error: %v", act, err)
-		}
-
-		fmt.Printf("✅ Generated service.%s: %s\n", act, filename)
+		files = append(files, outputFile{path: filename, code: code, label: "service." + act})
 	}
 
-	return nil
+	return files, nil
 }
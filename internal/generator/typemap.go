@@ -0,0 +1,656 @@
+package generator
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rifqiakrm/starter-cli/internal/config"
+	"github.com/rifqiakrm/starter-cli/internal/naming"
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// typeKind is the Go-facing family of a SQL column type
+type typeKind int
+
+const (
+	kindOther typeKind = iota // unknown types are mapped to string
+	kindUUID
+	kindULID
+	kindString
+	kindTime
+	kindInt
+	kindUint
+	kindBool
+	kindFloat
+	kindDecimal
+	kindBytes
+	kindJSON
+	kindInet
+	kindTimeOfDay
+	kindStringArray
+	kindIntArray
+	kindFloatArray
+	kindBoolArray
+)
+
+// postgresTypes are the built-in type_mappings entries of PostgreSQL. Arrays and
+// range types come first since TEXT* also matches TEXT[] and INT* matches
+// INT4RANGE, INTERVAL comes before INT* and TIME* (time of day) after
+// TIMESTAMP*.
+var postgresTypes = []typeMapping{
+	{"INTERVAL*[]", kindStringArray, stringArrayType},
+	{"BOOL*[]", kindBoolArray, boolArrayType},
+	{"INT*[]", kindIntArray, intArrayType},
+	{"SMALLINT*[]", kindIntArray, intArrayType},
+	{"BIGINT*[]", kindIntArray, intArrayType},
+	{"REAL[]", kindFloatArray, floatArrayType},
+	{"FLOAT*[]", kindFloatArray, floatArrayType},
+	{"DOUBLE PRECISION[]", kindFloatArray, floatArrayType},
+	{"NUMERIC*[]", kindFloatArray, floatArrayType},
+	{"DECIMAL*[]", kindFloatArray, floatArrayType},
+	{"*[]", kindStringArray, stringArrayType},
+	{"*RANGE", kindString, stringType}, // range and multirange types, read as their text form
+	{"UUID*", kindUUID, uuidType},
+	{"ULID*", kindULID, ulidType}, // the pgx_ulid extension type
+	{"VARCHAR*", kindString, stringType},
+	{"CHAR*", kindString, stringType}, // CHAR, CHARACTER and CHARACTER VARYING
+	{"BPCHAR*", kindString, stringType},
+	{"TEXT*", kindString, stringType},
+	{"CITEXT*", kindString, stringType},
+	{"DATE", kindTime, timeType},
+	{"TIMESTAMP*", kindTime, timeType},
+	{"TIME*", kindTimeOfDay, timeOfDayType},
+	{"INTERVAL*", kindString, stringType},
+	{"INT*", kindInt, intType},
+	{"SMALLINT*", kindInt, intType},
+	{"BIGINT*", kindInt, intType},
+	{"SERIAL*", kindInt, intType},
+	{"SMALLSERIAL*", kindInt, intType},
+	{"BIGSERIAL*", kindInt, intType},
+	{"BOOL*", kindBool, boolType},
+	{"REAL", kindFloat, floatType},
+	{"FLOAT*", kindFloat, floatType},
+	{"DOUBLE PRECISION", kindFloat, floatType},
+	{"NUMERIC*", kindDecimal, decimalType},
+	{"DECIMAL*", kindDecimal, decimalType},
+	{"MONEY", kindString, stringType},
+	{"JSON*", kindJSON, jsonType},
+	{"BYTEA", kindBytes, bytesType},
+	{"INET", kindInet, inetType},
+	{"CIDR", kindString, stringType},
+	{"MACADDR*", kindString, stringType},
+}
+
+// mysqlTypes are the built-in type_mappings entries of MySQL: TINYINT(1) is the
+// conventional boolean, DATETIME is a time and TIME a time of day. UNSIGNED integers map to
+// unsigned Go types, see kind.
+var mysqlTypes = []typeMapping{
+	{"TINYINT(1)", kindBool, boolType},
+	{"BOOL*", kindBool, boolType},
+	{"TINYINT*", kindInt, intType},
+	{"SMALLINT*", kindInt, intType},
+	{"MEDIUMINT*", kindInt, intType},
+	{"INT*", kindInt, intType},
+	{"BIGINT*", kindInt, intType},
+	{"YEAR*", kindInt, intType},
+	{"DATE", kindTime, timeType},
+	{"DATETIME*", kindTime, timeType},
+	{"TIMESTAMP*", kindTime, timeType},
+	{"TIME*", kindTimeOfDay, timeOfDayType},
+	{"FLOAT*", kindFloat, floatType},
+	{"DOUBLE*", kindFloat, floatType},
+	{"REAL*", kindFloat, floatType},
+	{"DECIMAL*", kindDecimal, decimalType},
+	{"NUMERIC*", kindDecimal, decimalType},
+	{"JSON", kindJSON, jsonType},
+	{"BINARY*", kindBytes, bytesType},
+	{"VARBINARY*", kindBytes, bytesType},
+	{"*BLOB", kindBytes, bytesType},
+	{"CHAR*", kindString, stringType},
+	{"VARCHAR*", kindString, stringType},
+	{"TINYTEXT*", kindString, stringType},
+	{"TEXT*", kindString, stringType},
+	{"MEDIUMTEXT*", kindString, stringType},
+	{"LONGTEXT*", kindString, stringType},
+	{"SET(*", kindString, stringType},
+}
+
+// sqliteTypes are the built-in type_mappings entries of SQLite, following column
+// affinity: INT means INTEGER, CHAR/CLOB/TEXT mean TEXT, BLOB or no type means
+// BLOB and REAL/FLOA/DOUB mean REAL. BOOLEAN and DATE/DATETIME/TIMESTAMP have
+// NUMERIC affinity but are conventionally stored as 0/1 and ISO-8601 text,
+// which the driver scans into bool and time.Time. DECIMAL, JSON and TIME are
+// read back as text.
+var sqliteTypes = []typeMapping{
+	{"UUID*", kindUUID, uuidType},
+	{"ULID*", kindULID, ulidType},
+	{"BOOL*", kindBool, boolType},
+	{"DATE", kindTime, timeType},
+	{"DATETIME*", kindTime, timeType},
+	{"TIMESTAMP*", kindTime, timeType},
+	{"TIME", kindTimeOfDay, timeOfDayType},
+	{"DECIMAL*", kindDecimal, decimalType},
+	{"NUMERIC*", kindDecimal, decimalType},
+	{"JSON*", kindJSON, jsonType},
+	{"*INT*", kindInt, intType},
+	{"*CHAR*", kindString, stringType},
+	{"*CLOB*", kindString, stringType},
+	{"*TEXT*", kindString, stringType},
+	{"", kindBytes, bytesType},
+	{"*BLOB*", kindBytes, bytesType},
+	{"*REAL*", kindFloat, floatType},
+	{"*FLOA*", kindFloat, floatType},
+	{"*DOUB*", kindFloat, floatType},
+}
+
+// The Go types of the built-in type_mappings entries, shared by the dialects
+var (
+	uuidType = config.TypeMapping{
+		GoType:             "uuid.UUID",
+		NullableGoType:     "uuid.NullUUID",
+		ResourceType:       "string",
+		FromEntity:         "{field}.String()",
+		NullableFromEntity: "{field}.UUID.String()",
+		Imports:            []string{"github.com/google/uuid"},
+	}
+	ulidType = config.TypeMapping{
+		GoType:       "ulid.ULID",
+		ResourceType: "string",
+		FromEntity:   "{field}.String()",
+		Imports:      []string{"github.com/oklog/ulid/v2"},
+	}
+	stringType = config.TypeMapping{
+		GoType:             "string",
+		NullableGoType:     "sql.NullString",
+		ResourceType:       "string",
+		FromEntity:         "{field}",
+		NullableFromEntity: "{field}.String",
+	}
+	timeType = config.TypeMapping{
+		GoType:             "time.Time",
+		NullableGoType:     "sql.NullTime",
+		ResourceType:       "string",
+		FromEntity:         "{field}.Format(constant.DefaultTimeFormat)",
+		NullableFromEntity: "{field}.Time.Format(constant.DefaultTimeFormat)",
+		ResourceImports:    []string{"{module}/common/constant"},
+	}
+	intType = config.TypeMapping{
+		GoType:             "int64",
+		NullableGoType:     "sql.NullInt64",
+		ResourceType:       "int64",
+		FromEntity:         "{field}",
+		NullableFromEntity: "{field}.Int64",
+	}
+	uintType = config.TypeMapping{
+		GoType:             "uint64",
+		NullableGoType:     "sql.NullInt64",
+		ResourceType:       "uint64",
+		FromEntity:         "{field}",
+		NullableFromEntity: "uint64({field}.Int64)",
+	}
+	boolType = config.TypeMapping{
+		GoType:             "bool",
+		NullableGoType:     "sql.NullBool",
+		ResourceType:       "bool",
+		FromEntity:         "{field}",
+		NullableFromEntity: "{field}.Bool",
+	}
+	floatType = config.TypeMapping{
+		GoType:             "float64",
+		NullableGoType:     "sql.NullFloat64",
+		ResourceType:       "float64",
+		FromEntity:         "{field}",
+		NullableFromEntity: "{field}.Float64",
+	}
+	decimalType = config.TypeMapping{
+		// decimals travel as strings so no precision is lost in JSON
+		GoType:             "decimal.Decimal",
+		NullableGoType:     "decimal.NullDecimal",
+		ResourceType:       "string",
+		RequestType:        "decimal.Decimal",
+		FromEntity:         "{field}.String()",
+		NullableFromEntity: "{field}.Decimal.String()",
+		Imports:            []string{"github.com/shopspring/decimal"},
+		ResourceImports:    []string{"github.com/shopspring/decimal"},
+	}
+	bytesType = config.TypeMapping{
+		// a nil slice stores NULL
+		GoType:       "[]byte",
+		ResourceType: "[]byte",
+		FromEntity:   "{field}",
+	}
+	jsonType = config.TypeMapping{
+		// a nil document stores NULL
+		GoType:          "datatypes.JSON",
+		NullableGoType:  "datatypes.JSON",
+		ResourceType:    "json.RawMessage",
+		FromEntity:      "json.RawMessage({field})",
+		Imports:         []string{"gorm.io/datatypes"},
+		ResourceImports: []string{"encoding/json"},
+	}
+	inetType = config.TypeMapping{
+		// drivers scan inet as text, the API validates and renders it as an IP
+		GoType:             "string",
+		NullableGoType:     "sql.NullString",
+		ResourceType:       "net.IP",
+		FromEntity:         "net.ParseIP({field})",
+		NullableFromEntity: "net.ParseIP({field}.String)",
+		ResourceImports:    []string{"net"},
+	}
+	timeOfDayType = config.TypeMapping{
+		// drivers scan TIME as text such as 15:04:05
+		GoType:             "string",
+		NullableGoType:     "sql.NullString",
+		ResourceType:       "string",
+		FromEntity:         "{field}",
+		NullableFromEntity: "{field}.String",
+	}
+	stringArrayType = config.TypeMapping{
+		// a nil array stores NULL
+		GoType:         "pq.StringArray",
+		NullableGoType: "pq.StringArray",
+		ResourceType:   "[]string",
+		FromEntity:     "[]string({field})",
+		Imports:        []string{"github.com/lib/pq"},
+	}
+	intArrayType = config.TypeMapping{
+		GoType:         "pq.Int64Array",
+		NullableGoType: "pq.Int64Array",
+		ResourceType:   "[]int64",
+		FromEntity:     "[]int64({field})",
+		Imports:        []string{"github.com/lib/pq"},
+	}
+	floatArrayType = config.TypeMapping{
+		GoType:         "pq.Float64Array",
+		NullableGoType: "pq.Float64Array",
+		ResourceType:   "[]float64",
+		FromEntity:     "[]float64({field})",
+		Imports:        []string{"github.com/lib/pq"},
+	}
+	boolArrayType = config.TypeMapping{
+		GoType:         "pq.BoolArray",
+		NullableGoType: "pq.BoolArray",
+		ResourceType:   "[]bool",
+		FromEntity:     "[]bool({field})",
+		Imports:        []string{"github.com/lib/pq"},
+	}
+	otherType = config.TypeMapping{
+		// unknown types are plain strings
+		GoType:             "string",
+		NullableGoType:     "sql.NullString",
+		ResourceType:       "string",
+		FromEntity:         "{field}",
+		NullableFromEntity: "{field}.String",
+	}
+)
+
+// typeMapping is a type_mappings entry. The built-in entries of the dialects
+// are the defaults behind the configured ones and also classify the types they
+// match, which configured entries leave to the built-in ones.
+type typeMapping struct {
+	pattern string
+	kind    typeKind
+	config.TypeMapping
+}
+
+// typeMapper maps SQL column types to Go entity, resource and request types
+// following the rules of a SQL dialect, the nullable style and the configured
+// type mappings
+type typeMapper struct {
+	dialect  types.Dialect
+	nullable types.NullableStyle
+	mappings []typeMapping // configured entries, most specific pattern first
+	builtins []typeMapping // built-in entries of the dialect, in order
+}
+
+// mapper returns the type mapper for the configured dialect, nullable style and type mappings
+func (g *Generator) mapper() typeMapper {
+	m := typeMapper{dialect: g.config.Dialect, nullable: g.config.NullableStyle, builtins: postgresTypes}
+	if m.nullable == "" {
+		m.nullable = types.NullableSQLNull
+	}
+	switch m.dialect {
+	case types.DialectMySQL:
+		m.builtins = mysqlTypes
+	case types.DialectSQLite:
+		m.builtins = sqliteTypes
+	}
+	for pattern, mapping := range g.config.TypeMappings {
+		m.mappings = append(m.mappings, typeMapping{pattern: pattern, TypeMapping: mapping})
+	}
+	// exact patterns win over wildcards, longer patterns over shorter ones
+	sort.Slice(m.mappings, func(i, j int) bool {
+		a, b := m.mappings[i].pattern, m.mappings[j].pattern
+		if wa, wb := strings.Contains(a, "*"), strings.Contains(b, "*"); wa != wb {
+			return wb
+		}
+		if len(a) != len(b) {
+			return len(a) > len(b)
+		}
+		return a < b
+	})
+	return m
+}
+
+// builtin returns the first built-in entry whose pattern matches the type of a
+// column, the unsigned one for UNSIGNED integers and a string one for unknown types
+func (m typeMapper) builtin(col types.Column) typeMapping {
+	for _, tm := range m.builtins {
+		if !matchType(tm.pattern, col.Type) {
+			continue
+		}
+		if tm.kind == kindInt && col.Unsigned {
+			return typeMapping{pattern: tm.pattern, kind: kindUint, TypeMapping: uintType}
+		}
+		return tm
+	}
+	return typeMapping{kind: kindOther, TypeMapping: otherType}
+}
+
+// kind classifies the SQL type of a column with the built-in entries of the dialect
+func (m typeMapper) kind(col types.Column) typeKind {
+	return m.builtin(col).kind
+}
+
+// mapping returns the Go types of a column: the first configured entry whose
+// pattern matches, or the built-in one
+func (m typeMapper) mapping(col types.Column) config.TypeMapping {
+	for _, tm := range m.mappings {
+		if matchType(tm.pattern, col.Type) {
+			return tm.TypeMapping
+		}
+	}
+	return m.builtin(col).TypeMapping
+}
+
+// known reports whether a column type is configured or has a built-in mapping
+func (m typeMapper) known(col types.Column) bool {
+	if col.Enum != nil || m.kind(col) != kindOther {
+		return true
+	}
+	for _, tm := range m.mappings {
+		if matchType(tm.pattern, col.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapped reports whether the Go type of a nullable column is wrapped as *T
+// or sql.Null[T]. Slices, maps and pointers store NULL themselves and are never
+// wrapped. The pointer and generic styles wrap every other type but those of
+// mappings whose nullable type is the type itself, the sqlnull style the types
+// of mappings without a nullable type.
+func (m typeMapper) wrapped(col types.Column) bool {
+	if !col.Nullable || col.Enum != nil {
+		return false
+	}
+	tm := m.mapping(col)
+	for _, prefix := range []string{"[]", "*", "map["} {
+		if strings.HasPrefix(tm.GoType, prefix) {
+			return false
+		}
+	}
+	if m.nullable == types.NullableSQLNull {
+		return tm.NullableGoType == ""
+	}
+	return tm.NullableGoType != tm.GoType
+}
+
+// goType returns the entity field type of a column
+func (m typeMapper) goType(col types.Column) string {
+	if col.Enum != nil {
+		// enum types store NULL as the empty value
+		return enumTypeName(col.Enum)
+	}
+
+	tm := m.mapping(col)
+	if m.wrapped(col) {
+		if m.nullable == types.NullablePointer {
+			return "*" + tm.GoType
+		}
+		return "sql.Null[" + tm.GoType + "]"
+	}
+	if col.Nullable && tm.NullableGoType != "" {
+		return tm.NullableGoType
+	}
+	return tm.GoType
+}
+
+// nullResource reports whether the resource field of a column is a pointer
+// that renders NULL as null, which the pointer and generic styles do
+func (m typeMapper) nullResource(col types.Column) bool {
+	// the audit timestamps of commonEntity.Auditable are never NULL
+	lowerName := strings.ToLower(col.Name)
+	return m.nullable != types.NullableSQLNull && m.wrapped(col) && lowerName != "created_at" && lowerName != "updated_at"
+}
+
+// baseResourceType returns the API type of the values of a column
+func (m typeMapper) baseResourceType(col types.Column) string {
+	if col.Enum != nil {
+		return "string"
+	}
+
+	if tm := m.mapping(col); tm.ResourceType != "" {
+		return tm.ResourceType
+	}
+	return "string"
+}
+
+// goResourceType returns the API resource field type of a column
+func (m typeMapper) goResourceType(col types.Column) string {
+	if m.nullResource(col) {
+		return "*" + m.baseResourceType(col)
+	}
+	return m.baseResourceType(col)
+}
+
+// goRequestType returns the create/update request field type of a column
+func (m typeMapper) goRequestType(col types.Column, required bool) string {
+	if col.Enum == nil {
+		if tm := m.mapping(col); tm.RequestType != "" {
+			return tm.RequestType
+		}
+	}
+	return m.baseResourceType(col)
+}
+
+// nullCheck returns the condition under which a nullable entity field holds a
+// value, when its resource field is only set under that condition, or ""
+func (m typeMapper) nullCheck(col types.Column) string {
+	if !m.nullResource(col) {
+		return ""
+	}
+
+	field := "e." + naming.Pascal(col.Name)
+	if m.nullable == types.NullableGeneric {
+		return field + ".Valid"
+	}
+
+	tm := m.mapping(col)
+	if (tm.FromEntity == "" || tm.FromEntity == "{field}") && m.baseResourceType(col) == tm.GoType {
+		// the pointer is assigned as is
+		return ""
+	}
+	return field + " != nil"
+}
+
+// hasNullChecks reports whether any resource field of a table needs a nullCheck
+func (m typeMapper) hasNullChecks(data TableData) bool {
+	for _, col := range data.Columns {
+		if !isSensitive(col.Name) && m.nullCheck(col) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// mapFromEntity returns the expression converting an entity field to its
+// resource type, or its value to the resource value type when the field is
+// behind a nullCheck
+func (m typeMapper) mapFromEntity(col types.Column) string {
+	field := "e." + naming.Pascal(col.Name)
+
+	if col.Enum != nil {
+		return "string(" + field + ")"
+	}
+
+	tm := m.mapping(col)
+	expr := tm.FromEntity
+	if expr == "" {
+		expr = "{field}"
+	}
+
+	switch {
+	case m.nullResource(col) && m.nullable == types.NullableGeneric:
+		field += ".V"
+	case m.nullResource(col):
+		if m.nullCheck(col) == "" {
+			return field
+		}
+		if !strings.HasPrefix(expr, "{field}.") {
+			// methods dereference the pointer themselves
+			field = "*" + field
+		}
+	case col.Nullable && tm.NullableFromEntity != "":
+		// the audit timestamps of commonEntity.Auditable are never NULL
+		lowerName := strings.ToLower(col.Name)
+		if lowerName != "created_at" && lowerName != "updated_at" {
+			expr = tm.NullableFromEntity
+		}
+	case m.nullable == types.NullableSQLNull && m.wrapped(col):
+		// the sqlnull style wraps mappings without a nullable type in sql.Null
+		field += ".V"
+	}
+	return strings.ReplaceAll(expr, "{field}", field)
+}
+
+// stdPackages are the standard library packages imported for the entity types
+// qualified with their name, so mappings only list other packages
+var stdPackages = map[string]string{
+	"sql":  "database/sql",
+	"time": "time",
+	"json": "encoding/json",
+	"net":  "net",
+	"big":  "math/big",
+}
+
+// qualifierPattern matches the package names qualifying a type, e.g. sql and
+// time in sql.Null[time.Time]
+var qualifierPattern = regexp.MustCompile(`([A-Za-z_][A-Za-z0-9_]*)\.`)
+
+// entityImports returns the packages the entity field types of a table need,
+// standard library first
+func (m typeMapper) entityImports(data TableData) []string {
+	var imports []string
+	for _, col := range data.Columns {
+		if col.Enum != nil || (!data.IsView && isAuditable(col.Name)) {
+			continue
+		}
+		imports = append(imports, m.mapping(col).Imports...)
+
+		for _, match := range qualifierPattern.FindAllStringSubmatch(m.goType(col), -1) {
+			if path := stdPackages[match[1]]; path != "" {
+				imports = append(imports, path)
+			}
+		}
+	}
+	return sortImports(imports, data.ModulePath)
+}
+
+// resourceImports returns the packages the resource and request types of a
+// table and their conversions need, standard library first
+func (m typeMapper) resourceImports(data TableData) []string {
+	var imports []string
+	for _, col := range data.Columns {
+		if col.Enum == nil {
+			imports = append(imports, m.mapping(col).ResourceImports...)
+		}
+	}
+	return sortImports(imports, data.ModulePath)
+}
+
+// sortImports resolves {module} to the module path, removes duplicates and
+// sorts import paths: standard library first, the module's own packages last
+func sortImports(imports []string, modulePath string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, imp := range imports {
+		imp = strings.ReplaceAll(imp, "{module}", modulePath)
+		if !seen[imp] {
+			seen[imp] = true
+			out = append(out, imp)
+		}
+	}
+
+	group := func(path string) int {
+		switch {
+		case path == modulePath || strings.HasPrefix(path, modulePath+"/"):
+			return 2
+		case isStdImport(path):
+			return 0
+		}
+		return 1
+	}
+	sort.Slice(out, func(i, j int) bool {
+		gi, gj := group(out[i]), group(out[j])
+		if gi != gj {
+			return gi < gj
+		}
+		return out[i] < out[j]
+	})
+	return out
+}
+
+// isStdImport reports whether an import path belongs to the standard library,
+// whose first path element has no dot
+func isStdImport(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+// matchType reports whether a SQL type matches a type pattern. Patterns are
+// case-insensitive and * matches any run of characters. A pattern without *
+// also matches the type without its arguments or schema, so "numeric" matches
+// NUMERIC(12,2) and "citext" matches public.citext.
+func matchType(pattern, sqlType string) bool {
+	pattern, sqlType = strings.ToUpper(pattern), strings.ToUpper(sqlType)
+	if strings.Contains(pattern, "*") {
+		return matchGlob(pattern, sqlType)
+	}
+	if sqlType == pattern {
+		return true
+	}
+
+	base := sqlType
+	if open := strings.Index(base, "("); open != -1 {
+		base = strings.TrimSpace(base[:open])
+	}
+	if base == pattern {
+		return true
+	}
+	if dot := strings.LastIndex(base, "."); dot != -1 {
+		return base[dot+1:] == pattern
+	}
+	return false
+}
+
+// matchGlob matches s against a pattern where * matches any run of characters
+func matchGlob(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		idx := strings.Index(s, part)
+		if idx == -1 {
+			return false
+		}
+		s = s[idx+len(part):]
+	}
+	return len(s) >= len(last) && strings.HasSuffix(s, last)
+}
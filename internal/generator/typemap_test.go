@@ -0,0 +1,194 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/rifqiakrm/starter-cli/internal/config"
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+func TestNullableStyles(t *testing.T) {
+	bio := types.Column{Name: "bio", Type: "TEXT", Nullable: true}
+	age := types.Column{Name: "age", Type: "INTEGER", Nullable: true}
+	owner := types.Column{Name: "owner_id", Type: "UUID", Nullable: true}
+	name := types.Column{Name: "name", Type: "VARCHAR(100)"}
+
+	tests := []struct {
+		style        types.NullableStyle
+		col          types.Column
+		goType       string
+		resourceType string
+		nullCheck    string
+		fromEntity   string
+	}{
+		{types.NullableSQLNull, bio, "sql.NullString", "string", "", "e.Bio.String"},
+		{types.NullableSQLNull, age, "sql.NullInt64", "int64", "", "e.Age.Int64"},
+		{types.NullableSQLNull, owner, "uuid.NullUUID", "string", "", "e.OwnerID.UUID.String()"},
+		{types.NullableSQLNull, name, "string", "string", "", "e.Name"},
+
+		{types.NullablePointer, bio, "*string", "*string", "", "e.Bio"},
+		{types.NullablePointer, age, "*int64", "*int64", "", "e.Age"},
+		{types.NullablePointer, owner, "*uuid.UUID", "*string", "e.OwnerID != nil", "e.OwnerID.String()"},
+		{types.NullablePointer, name, "string", "string", "", "e.Name"},
+
+		{types.NullableGeneric, bio, "sql.Null[string]", "*string", "e.Bio.Valid", "e.Bio.V"},
+		{types.NullableGeneric, age, "sql.Null[int64]", "*int64", "e.Age.Valid", "e.Age.V"},
+		{types.NullableGeneric, owner, "sql.Null[uuid.UUID]", "*string", "e.OwnerID.Valid", "e.OwnerID.V.String()"},
+		{types.NullableGeneric, name, "string", "string", "", "e.Name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.style)+"/"+tt.col.Name, func(t *testing.T) {
+			m := NewGenerator(&config.Config{Dialect: types.DialectPostgres, NullableStyle: tt.style}).mapper()
+
+			if got := m.goType(tt.col); got != tt.goType {
+				t.Errorf("goType() = %q, want %q", got, tt.goType)
+			}
+			if got := m.goResourceType(tt.col); got != tt.resourceType {
+				t.Errorf("goResourceType() = %q, want %q", got, tt.resourceType)
+			}
+			if got := m.nullCheck(tt.col); got != tt.nullCheck {
+				t.Errorf("nullCheck() = %q, want %q", got, tt.nullCheck)
+			}
+			if got := m.mapFromEntity(tt.col); got != tt.fromEntity {
+				t.Errorf("mapFromEntity() = %q, want %q", got, tt.fromEntity)
+			}
+		})
+	}
+}
+
+func TestDialectTypes(t *testing.T) {
+	tests := []struct {
+		dialect types.Dialect
+		col     types.Column
+		want    string
+	}{
+		{types.DialectPostgres, types.Column{Type: "TIMESTAMPTZ"}, "time.Time"},
+		{types.DialectPostgres, types.Column{Type: "INTERVAL"}, "string"},
+		{types.DialectPostgres, types.Column{Type: "DATE"}, "time.Time"},
+		{types.DialectPostgres, types.Column{Type: "DATERANGE"}, "string"},
+		{types.DialectPostgres, types.Column{Type: "INT4RANGE"}, "string"},
+		{types.DialectPostgres, types.Column{Type: "TSTZMULTIRANGE"}, "string"},
+		{types.DialectPostgres, types.Column{Type: "TEXT[]"}, "pq.StringArray"},
+		{types.DialectPostgres, types.Column{Type: "BIGINT", Unsigned: true}, "uint64"},
+		{types.DialectMySQL, types.Column{Type: "TINYINT(1)"}, "bool"},
+		{types.DialectMySQL, types.Column{Type: "BINARY(16)"}, "[]byte"},
+		{types.DialectMySQL, types.Column{Type: "DATE"}, "time.Time"},
+		{types.DialectMySQL, types.Column{Type: "DATETIME(6)"}, "time.Time"},
+		{types.DialectSQLite, types.Column{Type: "INTEGER"}, "int64"},
+		{types.DialectSQLite, types.Column{Type: "DATETIME"}, "time.Time"},
+		{types.DialectPostgres, types.Column{Type: "GEOMETRY"}, "string"},
+	}
+
+	for _, tt := range tests {
+		m := NewGenerator(&config.Config{Dialect: tt.dialect}).mapper()
+		if got := m.goType(tt.col); got != tt.want {
+			t.Errorf("%s goType(%s) = %q, want %q", tt.dialect, tt.col.Type, got, tt.want)
+		}
+	}
+}
+
+func TestTypeMappings(t *testing.T) {
+	m := NewGenerator(&config.Config{
+		Dialect:       types.DialectPostgres,
+		NullableStyle: types.NullablePointer,
+		TypeMappings: map[string]config.TypeMapping{
+			"NUMERIC*": {
+				GoType:       "decimal.Decimal",
+				ResourceType: "string",
+				FromEntity:   "{field}.String()",
+				Imports:      []string{"github.com/shopspring/decimal"},
+			},
+			"NUMERIC(10,2)": {GoType: "money.Amount", ResourceType: "int64", FromEntity: "{field}.Cents()"},
+		},
+	}).mapper()
+
+	tests := []struct {
+		col        types.Column
+		goType     string
+		fromEntity string
+	}{
+		{types.Column{Name: "rate", Type: "NUMERIC(5,4)"}, "decimal.Decimal", "e.Rate.String()"},
+		{types.Column{Name: "price", Type: "NUMERIC(10,2)"}, "money.Amount", "e.Price.Cents()"},
+		{types.Column{Name: "discount", Type: "NUMERIC(5,4)", Nullable: true}, "*decimal.Decimal", "e.Discount.String()"},
+	}
+
+	for _, tt := range tests {
+		if got := m.goType(tt.col); got != tt.goType {
+			t.Errorf("goType(%s) = %q, want %q", tt.col.Name, got, tt.goType)
+		}
+		if got := m.mapFromEntity(tt.col); got != tt.fromEntity {
+			t.Errorf("mapFromEntity(%s) = %q, want %q", tt.col.Name, got, tt.fromEntity)
+		}
+	}
+	if !m.known(types.Column{Type: "NUMERIC"}) {
+		t.Error("known(NUMERIC) = false, want true")
+	}
+}
+
+func TestTypeMappingsWithoutNullableType(t *testing.T) {
+	mappings := map[string]config.TypeMapping{"citext": {GoType: "string"}}
+	nickname := types.Column{Name: "nickname", Type: "CITEXT", Nullable: true}
+	ref := types.Column{Name: "ref", Type: "ULID", Nullable: true}
+
+	tests := []struct {
+		style        types.NullableStyle
+		col          types.Column
+		goType       string
+		resourceType string
+		fromEntity   string
+		value        string
+	}{
+		{types.NullableSQLNull, nickname, "sql.Null[string]", "string", "e.Nickname.V", ".Nickname.V"},
+		{types.NullableSQLNull, ref, "sql.Null[ulid.ULID]", "string", "e.Ref.V.String()", ".Ref.V"},
+		{types.NullablePointer, nickname, "*string", "*string", "e.Nickname", ".Nickname"},
+		{types.NullableGeneric, nickname, "sql.Null[string]", "*string", "e.Nickname.V", ".Nickname.V"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.style)+"/"+tt.col.Name, func(t *testing.T) {
+			g := NewGenerator(&config.Config{Dialect: types.DialectPostgres, NullableStyle: tt.style, TypeMappings: mappings})
+			m := g.mapper()
+
+			if got := m.goType(tt.col); got != tt.goType {
+				t.Errorf("goType() = %q, want %q", got, tt.goType)
+			}
+			if got := m.goResourceType(tt.col); got != tt.resourceType {
+				t.Errorf("goResourceType() = %q, want %q", got, tt.resourceType)
+			}
+			if got := m.mapFromEntity(tt.col); got != tt.fromEntity {
+				t.Errorf("mapFromEntity() = %q, want %q", got, tt.fromEntity)
+			}
+			if got := g.finderColumn(tt.col).Value; got != tt.value {
+				t.Errorf("finderColumn().Value = %q, want %q", got, tt.value)
+			}
+		})
+	}
+}
+
+func TestTypeMappingsOverrideBuiltins(t *testing.T) {
+	m := NewGenerator(&config.Config{
+		Dialect: types.DialectPostgres,
+		TypeMappings: map[string]config.TypeMapping{
+			"TEXT*": {GoType: "mytypes.Text", NullableGoType: "mytypes.NullText"},
+		},
+	}).mapper()
+
+	tests := []struct {
+		col    types.Column
+		goType string
+	}{
+		{types.Column{Type: "TEXT"}, "mytypes.Text"},
+		{types.Column{Type: "TEXT", Nullable: true}, "mytypes.NullText"},
+		{types.Column{Type: "VARCHAR(100)"}, "string"},
+	}
+	for _, tt := range tests {
+		if got := m.goType(tt.col); got != tt.goType {
+			t.Errorf("goType(%s) = %q, want %q", tt.col.Type, got, tt.goType)
+		}
+	}
+	// the built-in entry still classifies the type
+	if got := m.kind(types.Column{Type: "TEXT"}); got != kindString {
+		t.Errorf("kind(TEXT) = %v, want kindString", got)
+	}
+}
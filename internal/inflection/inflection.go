@@ -0,0 +1,190 @@
+// Package inflection pluralizes and singularizes the English nouns table,
+// entity and route names are made of. Only the last word of snake_case,
+// kebab-case and PascalCase names is inflected and its case is kept, so
+// user_role becomes user_roles and OrderItem becomes OrderItems.
+package inflection
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// rule rewrites the words matching a suffix pattern
+type rule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+func newRules(pairs ...string) []rule {
+	rules := make([]rule, 0, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		rules = append(rules, rule{regexp.MustCompile(pairs[i]), pairs[i+1]})
+	}
+	return rules
+}
+
+// pluralRules and singularRules are tried in order, the first match wins
+var pluralRules = newRules(
+	`^(ox)$`, "${1}en",
+	`^(m|l)ouse$`, "${1}ice",
+	`(matr|vert|ind)(ix|ex)$`, "${1}ices",
+	`(quiz)$`, "${1}zes",
+	`(alias|status|campus|bonus|virus|canvas|^gas)$`, "${1}es",
+	`^(bu)s$`, "${1}ses",
+	`(buffal|tomat|potat|her|ech)o$`, "${1}oes",
+	`^(ax|test|cris)is$`, "${1}es",
+	`sis$`, "ses",
+	`(wi|kni|^li)fe$`, "${1}ves",
+	`(wol|shel|hal|cal|lea|loa|thie|sel|el)f$`, "${1}ves",
+	`([^aeiouy]|qu)y$`, "${1}ies",
+	`(x|ch|ss|sh|z)$`, "${1}es",
+	`s$`, "s",
+	`$`, "s",
+)
+
+var singularRules = newRules(
+	`^(ox)en$`, "${1}",
+	`^(m|l)ice$`, "${1}ouse",
+	`(matr)ices$`, "${1}ix",
+	`(vert|ind)ices$`, "${1}ex",
+	`(quiz)zes$`, "${1}",
+	`(alias|status|campus|bonus|virus|canvas|^gas)(es)?$`, "${1}",
+	`^(bus)(es)?$`, "${1}",
+	`(buffal|tomat|potat|her|ech)oes$`, "${1}o",
+	`^(ax|test|cris)es$`, "${1}is",
+	`(database)s$`, "${1}",
+	`(analy|^ba|diagno|parenthe|progno|synop|^the)ses$`, "${1}sis",
+	`(wi|kni|^li)ves$`, "${1}fe",
+	`(wol|shel|hal|cal|lea|loa|thie|sel|el)ves$`, "${1}f",
+	`(m)ovies$`, "${1}ovie",
+	`([^aeiouy]|qu)ies$`, "${1}y",
+	`(x|ch|ss|sh|zz)es$`, "${1}",
+	`(ss|is)$`, "${1}",
+	`s$`, "",
+)
+
+// irregulars maps singular nouns to their plural, plurals the other way round
+var (
+	irregulars = map[string]string{}
+	plurals    = map[string]string{}
+)
+
+// uncountables are nouns whose plural is the singular
+var uncountables = map[string]bool{}
+
+func init() {
+	for singular, plural := range map[string]string{
+		"person": "people",
+		"man":    "men",
+		"woman":  "women",
+		"child":  "children",
+		"tooth":  "teeth",
+		"foot":   "feet",
+		"goose":  "geese",
+		"datum":  "data",
+		"course": "courses",
+		"move":   "moves",
+		"cookie": "cookies",
+	} {
+		AddIrregular(singular, plural)
+	}
+	AddUncountable("equipment", "information", "money", "species", "series", "fish", "sheep",
+		"news", "metadata", "feedback", "media", "audio", "software")
+}
+
+// AddIrregular registers the plural of a noun that does not follow the
+// rules, replacing any previous registration of either form
+func AddIrregular(singular, plural string) {
+	singular, plural = strings.ToLower(singular), strings.ToLower(plural)
+	irregulars[singular] = plural
+	plurals[plural] = singular
+	delete(uncountables, singular)
+	delete(uncountables, plural)
+}
+
+// AddUncountable registers nouns that have no plural form
+func AddUncountable(words ...string) {
+	for _, word := range words {
+		uncountables[strings.ToLower(word)] = true
+	}
+}
+
+// Plural returns the plural of a name, which is returned as is when it
+// already is plural
+func Plural(name string) string {
+	return inflect(name, func(word string) string {
+		if plural, ok := irregulars[word]; ok {
+			return plural
+		}
+		if _, ok := plurals[word]; ok {
+			return word
+		}
+		if apply(pluralRules, apply(singularRules, word)) == word {
+			// a plural the rules produce, such as mice
+			return word
+		}
+		return apply(pluralRules, word)
+	})
+}
+
+// Singular returns the singular of a name, which is returned as is when it
+// already is singular
+func Singular(name string) string {
+	return inflect(name, func(word string) string {
+		if singular, ok := plurals[word]; ok {
+			return singular
+		}
+		if _, ok := irregulars[word]; ok {
+			return word
+		}
+		return apply(singularRules, word)
+	})
+}
+
+// inflect applies fn to the lowercased last word of name and restores its case
+func inflect(name string, fn func(string) string) string {
+	start := lastWord(name)
+	prefix, word := name[:start], name[start:]
+	lower := strings.ToLower(word)
+	if lower == "" || uncountables[lower] {
+		return name
+	}
+
+	inflected := fn(lower)
+	if inflected == "" {
+		// a word like "s" has no singular
+		return name
+	}
+	switch {
+	case word == strings.ToUpper(word) && len(word) > 1:
+		inflected = strings.ToUpper(inflected)
+	case unicode.IsUpper(rune(word[0])):
+		inflected = strings.ToUpper(inflected[:1]) + inflected[1:]
+	}
+	return prefix + inflected
+}
+
+// lastWord returns the index the last word of a snake_case, kebab-case,
+// spaced or PascalCase name starts at
+func lastWord(name string) int {
+	for i := len(name) - 1; i > 0; i-- {
+		switch c := rune(name[i]); {
+		case c == '_' || c == '-' || c == ' ' || c == '.':
+			return i + 1
+		case unicode.IsUpper(c) && (!unicode.IsUpper(rune(name[i-1])) ||
+			i+1 < len(name) && unicode.IsLower(rune(name[i+1]))):
+			return i
+		}
+	}
+	return 0
+}
+
+func apply(rules []rule, word string) string {
+	for _, r := range rules {
+		if r.pattern.MatchString(word) {
+			return r.pattern.ReplaceAllString(word, r.replacement)
+		}
+	}
+	return word
+}
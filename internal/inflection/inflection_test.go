@@ -0,0 +1,108 @@
+package inflection
+
+import "testing"
+
+func TestPluralAndSingular(t *testing.T) {
+	tests := []struct {
+		singular string
+		plural   string
+	}{
+		{"user", "users"},
+		{"category", "categories"},
+		{"day", "days"},
+		{"status", "statuses"},
+		{"address", "addresses"},
+		{"box", "boxes"},
+		{"branch", "branches"},
+		{"quiz", "quizzes"},
+		{"analysis", "analyses"},
+		{"basis", "bases"},
+		{"database", "databases"},
+		{"canvas", "canvases"},
+		{"gas", "gases"},
+		{"knife", "knives"},
+		{"life", "lives"},
+		{"olive", "olives"},
+		{"midwife", "midwives"},
+		{"shelf", "shelves"},
+		{"hero", "heroes"},
+		{"matrix", "matrices"},
+		{"index", "indices"},
+		{"mouse", "mice"},
+		{"person", "people"},
+		{"child", "children"},
+		{"datum", "data"},
+		{"move", "moves"},
+		{"cookie", "cookies"},
+		{"equipment", "equipment"},
+		{"metadata", "metadata"},
+		{"user_role", "user_roles"},
+		{"user_database", "user_databases"},
+		{"LegacyDatabase", "LegacyDatabases"},
+		{"order-item", "order-items"},
+		{"OrderItem", "OrderItems"},
+		{"SalesPerson", "SalesPeople"},
+		{"USER", "USERS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.singular, func(t *testing.T) {
+			if got := Plural(tt.singular); got != tt.plural {
+				t.Errorf("Plural(%q) = %q, want %q", tt.singular, got, tt.plural)
+			}
+			if got := Singular(tt.plural); got != tt.singular {
+				t.Errorf("Singular(%q) = %q, want %q", tt.plural, got, tt.singular)
+			}
+			// inflecting a name that already has the form keeps it
+			if got := Plural(tt.plural); got != tt.plural {
+				t.Errorf("Plural(%q) = %q, want it unchanged", tt.plural, got)
+			}
+			if got := Singular(tt.singular); got != tt.singular {
+				t.Errorf("Singular(%q) = %q, want it unchanged", tt.singular, got)
+			}
+		})
+	}
+}
+
+func TestSingularWithoutSingular(t *testing.T) {
+	// the last word "s" has no singular, so the name is kept
+	for _, name := range []string{"UserS", "user_s", "S"} {
+		if got := Singular(name); got != name {
+			t.Errorf("Singular(%q) = %q, want it unchanged", name, got)
+		}
+	}
+}
+
+func TestAddIrregular(t *testing.T) {
+	AddIrregular("Cactus", "Cacti")
+
+	tests := []struct {
+		fn   func(string) string
+		name string
+		in   string
+		want string
+	}{
+		{Plural, "Plural", "cactus", "cacti"},
+		{Plural, "Plural", "garden_cactus", "garden_cacti"},
+		{Singular, "Singular", "cacti", "cactus"},
+		{Singular, "Singular", "GardenCacti", "GardenCactus"},
+	}
+	for _, tt := range tests {
+		if got := tt.fn(tt.in); got != tt.want {
+			t.Errorf("%s(%q) = %q, want %q", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAddUncountable(t *testing.T) {
+	AddUncountable("Staff")
+
+	for _, name := range []string{"staff", "support_staff", "SupportStaff"} {
+		if got := Plural(name); got != name {
+			t.Errorf("Plural(%q) = %q, want it unchanged", name, got)
+		}
+		if got := Singular(name); got != name {
+			t.Errorf("Singular(%q) = %q, want it unchanged", name, got)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Package naming converts table, column and entity names to Go identifiers.
+// Words are split at underscores, dashes, spaces and case changes, and the
+// words listed as initialisms are written in a consistent case as golint
+// expects: org_unit_id becomes OrgUnitID and orgUnitID, api_urls becomes
+// APIURLs.
+package naming
+
+import (
+	"strings"
+	"unicode"
+)
+
+// initialisms are the upper case words of golint, plus the configured ones
+var initialisms = map[string]bool{}
+
+func init() {
+	AddInitialisms("ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP", "HTTPS", "ID",
+		"IP", "JSON", "LHS", "QPS", "RAM", "RHS", "RPC", "SLA", "SMTP", "SQL", "SSH", "TCP", "TLS", "TTL",
+		"UDP", "UI", "UID", "UUID", "URI", "URL", "UTF8", "VM", "XML", "XMPP", "XSRF", "XSS")
+}
+
+// AddInitialisms registers words that are written in upper case, e.g. SKU
+func AddInitialisms(words ...string) {
+	for _, word := range words {
+		initialisms[strings.ToUpper(strings.TrimSpace(word))] = true
+	}
+}
+
+// Pascal converts a name to an exported Go identifier, e.g. OrgUnitID. Names
+// starting with a digit are prefixed with X, so 2fa_code becomes X2faCode.
+func Pascal(name string) string {
+	var b strings.Builder
+	for _, word := range Words(name) {
+		if b.Len() == 0 && unicode.IsDigit(rune(word[0])) {
+			b.WriteString("X")
+		}
+		b.WriteString(upperWord(word))
+	}
+	return b.String()
+}
+
+// Camel converts a name to an unexported Go identifier, e.g. orgUnitID. A
+// leading initialism is written in lower case, so id_token becomes idToken,
+// and a leading digit is prefixed with x.
+func Camel(name string) string {
+	var b strings.Builder
+	for i, word := range Words(name) {
+		if i == 0 {
+			if unicode.IsDigit(rune(word[0])) {
+				b.WriteString("x")
+			}
+			b.WriteString(strings.ToLower(word))
+			continue
+		}
+		b.WriteString(upperWord(word))
+	}
+	return b.String()
+}
+
+// upperWord capitalizes a word, or upper cases it when it is an initialism
+// or the plural of one (IDs, URLs)
+func upperWord(word string) string {
+	upper := strings.ToUpper(word)
+	switch {
+	case initialisms[upper]:
+		return upper
+	case len(upper) > 2 && strings.HasSuffix(upper, "S") && initialisms[upper[:len(upper)-1]]:
+		return upper[:len(upper)-1] + "s"
+	}
+	lower := strings.ToLower(word)
+	return strings.ToUpper(lower[:1]) + lower[1:]
+}
+
+// pluralEnd reports whether runes[i] is the s ending a plural initialism such as IDs
+func pluralEnd(runes []rune, i int) bool {
+	return runes[i] == 's' && (i+1 == len(runes) || !unicode.IsLower(runes[i+1]))
+}
+
+// Words splits a snake_case, kebab-case, spaced, camelCase or PascalCase name
+// into its words. Digits stay with the word they follow and runs of capitals
+// form one word, so HTTPServer2 is HTTP and Server2.
+func Words(name string) []string {
+	var words []string
+	runes := []rune(name)
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start != -1 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start == -1 {
+			start = i
+			continue
+		}
+		prev := runes[i-1]
+		lowerToUpper := unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev))
+		acronymEnd := unicode.IsUpper(r) && unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) &&
+			!pluralEnd(runes, i+1)
+		if lowerToUpper || acronymEnd {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start != -1 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
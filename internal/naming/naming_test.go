@@ -0,0 +1,73 @@
+package naming
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"org_unit_id", []string{"org", "unit", "id"}},
+		{"order-items", []string{"order", "items"}},
+		{"first name", []string{"first", "name"}},
+		{"orgUnitID", []string{"org", "Unit", "ID"}},
+		{"HTTPServer2", []string{"HTTP", "Server2"}},
+		{"userIDs", []string{"user", "IDs"}},
+		{"APIURLs", []string{"APIURLs"}},
+		{"address2_line", []string{"address2", "line"}},
+		{"__id__", []string{"id"}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		if got := Words(tt.name); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Words(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPascalAndCamel(t *testing.T) {
+	tests := []struct {
+		name   string
+		pascal string
+		camel  string
+	}{
+		{"users", "Users", "users"},
+		{"org_unit_id", "OrgUnitID", "orgUnitID"},
+		{"api_urls", "APIURLs", "apiURLs"},
+		{"id_token", "IDToken", "idToken"},
+		{"user_ids", "UserIDs", "userIDs"},
+		{"userIDs", "UserIDs", "userIDs"},
+		{"http_server2", "HTTPServer2", "httpServer2"},
+		{"HTTPServer2", "HTTPServer2", "httpServer2"},
+		{"2fa_code", "X2faCode", "x2faCode"},
+		{"ORDER_ITEMS", "OrderItems", "orderItems"},
+		{"is", "Is", "is"},
+	}
+
+	for _, tt := range tests {
+		if got := Pascal(tt.name); got != tt.pascal {
+			t.Errorf("Pascal(%q) = %q, want %q", tt.name, got, tt.pascal)
+		}
+		if got := Camel(tt.name); got != tt.camel {
+			t.Errorf("Camel(%q) = %q, want %q", tt.name, got, tt.camel)
+		}
+	}
+}
+
+func TestAddInitialisms(t *testing.T) {
+	AddInitialisms(" sku ")
+
+	if got := Pascal("product_sku"); got != "ProductSKU" {
+		t.Errorf("Pascal(%q) = %q, want %q", "product_sku", got, "ProductSKU")
+	}
+	if got := Camel("sku_code"); got != "skuCode" {
+		t.Errorf("Camel(%q) = %q, want %q", "sku_code", got, "skuCode")
+	}
+	if got := Pascal("skus"); got != "SKUs" {
+		t.Errorf("Pascal(%q) = %q, want %q", "skus", got, "SKUs")
+	}
+}
@@ -0,0 +1,430 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// setTypeModifiers fills length, precision and scale from the column type,
+// e.g. VARCHAR(255) or NUMERIC(12,2)
+func setTypeModifiers(col *types.Column) {
+	col.Length, col.Precision, col.Scale = 0, 0, 0
+
+	base := strings.TrimSpace(col.Type)
+	args := ""
+	if open := strings.Index(base, "("); open != -1 {
+		if inner, _, ok := parenthesized(base); ok {
+			args = inner
+		}
+		base = strings.TrimSpace(base[:open])
+	}
+
+	var nums []int
+	for _, part := range strings.Split(args, ",") {
+		if n, err := strconv.Atoi(strings.TrimSpace(part)); err == nil {
+			nums = append(nums, n)
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(col.Type, "]"):
+		// the modifiers of an array type apply to its elements
+	case strings.HasPrefix(base, "SERIAL"), strings.HasPrefix(base, "BIGSERIAL"), strings.HasPrefix(base, "SMALLSERIAL"):
+		col.Identity = true
+	case strings.HasPrefix(base, "NUMERIC"), strings.HasPrefix(base, "DECIMAL"):
+		if len(nums) > 0 {
+			col.Precision = nums[0]
+		}
+		if len(nums) > 1 {
+			col.Scale = nums[1]
+		}
+	case strings.Contains(base, "CHAR"), strings.HasPrefix(base, "BIT"), strings.HasPrefix(base, "VARBINARY"):
+		if len(nums) > 0 {
+			col.Length = nums[0]
+		}
+	}
+}
+
+// columnKeywords start a column constraint or option and end the column type.
+// The dialect specific options are only keywords in their dialect.
+var columnKeywords = map[types.Dialect][]string{
+	"":                    {"not", "null", "default", "primary", "unique", "references", "check", "constraint", "generated", "collate", "as", "deferrable", "initially"},
+	types.DialectPostgres: {"storage", "compression"},
+	types.DialectMySQL: {"auto_increment", "comment", "key", "charset", "visible", "invisible", "column_format", "srid",
+		"engine_attribute", "secondary_engine_attribute"},
+	types.DialectSQLite: {"autoincrement"},
+}
+
+// isColumnKeyword reports whether the current token starts a column constraint or option
+func (p *ddlParser) isColumnKeyword() bool {
+	tok := p.peek()
+	if tok.kind != tokWord {
+		return false
+	}
+	switch {
+	case tok.is("on"):
+		// MySQL ON UPDATE and SQLite ON CONFLICT
+		return p.peekAt(1).is("update") || p.peekAt(1).is("conflict")
+	case tok.is("character"):
+		// CHARACTER alone is a type, CHARACTER SET a MySQL option
+		return p.peekAt(1).is("set")
+	}
+	for _, dialect := range []types.Dialect{"", p.dialect} {
+		for _, kw := range columnKeywords[dialect] {
+			if tok.is(kw) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// atElementEnd reports whether the current column definition is complete.
+// MySQL ALTER TABLE also places columns with FIRST or AFTER <column>.
+func (p *ddlParser) atElementEnd() bool {
+	tok := p.peek()
+	return tok.kind == tokEOF || tok.isPunct(",") || tok.isPunct(")") ||
+		p.dialect == types.DialectMySQL && (tok.is("first") || tok.is("after"))
+}
+
+// columnDef parses "name type [constraints...]" into a Column and the inline
+// REFERENCES and UNIQUE constraints, which are recorded on the table like
+// table constraints
+func (p *ddlParser) columnDef() (types.Column, []*tableConstraint, error) {
+	name, err := p.ident("column name")
+	if err != nil {
+		return types.Column{}, nil, err
+	}
+
+	col := types.Column{Name: name, Nullable: true}
+	if col.Type, err = p.dataType(&col); err != nil {
+		return types.Column{}, nil, err
+	}
+	// SQLite columns may omit the type
+	if col.Type == "" && p.dialect != types.DialectSQLite {
+		tok := p.peek()
+		return types.Column{}, nil, p.errorf(tok, "expected type of column %s, found %s", name, tok.describe())
+	}
+	setTypeModifiers(&col)
+
+	constraints, err := p.columnConstraints(&col)
+	if err != nil {
+		return types.Column{}, nil, err
+	}
+	if col.PrimaryKey {
+		// primary key columns are implicitly unique and NOT NULL
+		col.Unique = true
+		col.Nullable = false
+	}
+	if col.Identity && p.dialect == types.DialectPostgres {
+		// identity and serial columns are implicitly NOT NULL
+		col.Nullable = false
+	}
+	return col, constraints, nil
+}
+
+// dataType reads a column type such as VARCHAR(255), NUMERIC(12, 2), TEXT[],
+// DOUBLE PRECISION, TIMESTAMP WITH TIME ZONE or public.citext. Keywords are
+// upper-cased and literals kept, so ENUM('a','b') keeps its values. MySQL
+// UNSIGNED marks the column instead of being part of the type.
+func (p *ddlParser) dataType(col *types.Column) (string, error) {
+	var sb strings.Builder
+	for {
+		tok := p.peek()
+		switch {
+		case tok.kind == tokWord && !p.isColumnKeyword() && !p.atElementEnd():
+			if p.dialect == types.DialectMySQL {
+				switch strings.ToUpper(tok.text) {
+				case "UNSIGNED":
+					col.Unsigned = true
+					p.i++
+					continue
+				case "SIGNED", "ZEROFILL":
+					p.i++
+					continue
+				}
+			}
+			if sb.Len() > 0 {
+				sb.WriteByte(' ')
+			}
+			sb.WriteString(strings.ToUpper(tok.text))
+			p.i++
+		case tok.kind == tokQuoted && sb.Len() == 0:
+			sb.WriteString(tok.value)
+			p.i++
+		case tok.isPunct(".") && sb.Len() > 0:
+			p.i++
+			name := p.peek()
+			if _, err := p.ident("type name"); err != nil {
+				return "", err
+			}
+			if name.kind == tokWord {
+				sb.WriteString("." + strings.ToUpper(name.value))
+			} else {
+				sb.WriteString("." + name.value)
+			}
+		case tok.isPunct("(") && sb.Len() > 0:
+			args, err := p.typeArgs()
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(args)
+		case tok.isPunct("[") && sb.Len() > 0:
+			p.i++
+			if p.peek().kind == tokNumber {
+				p.i++
+			}
+			if err := p.expectPunct("]"); err != nil {
+				return "", err
+			}
+			sb.WriteString("[]")
+		default:
+			return sb.String(), nil
+		}
+	}
+}
+
+// typeArgs reads the parenthesized arguments of a type, e.g. (12,2) or ('a','b')
+func (p *ddlParser) typeArgs() (string, error) {
+	open := p.next()
+	var sb strings.Builder
+	sb.WriteByte('(')
+	depth := 1
+	spaced := false // the previous argument token was a word or number
+	for {
+		tok := p.next()
+		word := tok.kind == tokWord || tok.kind == tokNumber
+		if word && spaced {
+			sb.WriteByte(' ')
+		}
+		spaced = word
+
+		switch {
+		case tok.kind == tokEOF:
+			return "", p.errorf(open, "unbalanced parenthesis in type")
+		case tok.isPunct("("):
+			depth++
+			sb.WriteByte('(')
+		case tok.isPunct(")"):
+			sb.WriteByte(')')
+			if depth--; depth == 0 {
+				return sb.String(), nil
+			}
+		case tok.kind == tokString:
+			sb.WriteString(quoteLiteral(tok.value))
+		case tok.kind == tokWord:
+			sb.WriteString(strings.ToUpper(tok.text))
+		default:
+			sb.WriteString(tok.text)
+		}
+	}
+}
+
+// columnConstraints reads the constraints and options following the column type.
+// Unknown clauses are reported and skipped up to the next known one.
+func (p *ddlParser) columnConstraints(col *types.Column) ([]*tableConstraint, error) {
+	var constraints []*tableConstraint
+	name := ""
+	for !p.atElementEnd() {
+		tok := p.peek()
+		switch {
+		case p.accept("constraint"):
+			n, err := p.ident("constraint name")
+			if err != nil {
+				return nil, err
+			}
+			name = n
+			continue
+		case p.accept("not", "null"):
+			col.Nullable = false
+		case p.accept("null"):
+		case p.accept("default"):
+			expr, err := p.expression("DEFAULT")
+			if err != nil {
+				return nil, err
+			}
+			col.Default = normalizeDefault(expr)
+		case p.accept("primary", "key"), p.dialect == types.DialectMySQL && p.accept("key"):
+			col.PrimaryKey = true
+			p.acceptAny("asc", "desc")
+		case p.accept("unique"):
+			col.Unique = true
+			p.accept("key")
+			p.acceptNullsDistinct()
+			constraints = append(constraints, &tableConstraint{kind: constraintUnique, name: name, columns: []string{col.Name}})
+		case tok.is("references"):
+			ref, err := p.references()
+			if err != nil {
+				return nil, err
+			}
+			ref.Name = name
+			ref.Columns = []string{col.Name}
+			constraints = append(constraints, &tableConstraint{kind: constraintForeignKey, name: name, columns: ref.Columns, fk: ref})
+		case p.accept("check"):
+			expr, err := p.group()
+			if err != nil {
+				return nil, err
+			}
+			col.Check = expr
+			p.accept("no", "inherit")
+			p.acceptEnforced()
+		case p.accept("generated"):
+			if err := p.generated(col); err != nil {
+				return nil, err
+			}
+		case tok.is("as") && p.peekAt(1).isPunct("("):
+			// MySQL and SQLite shorthand for generated columns
+			p.i++
+			if _, err := p.group(); err != nil {
+				return nil, err
+			}
+			col.Generated = true
+			p.acceptAny("stored", "virtual", "persistent")
+		case p.accept("collate"):
+			if _, _, err := p.qualifiedName("collation"); err != nil {
+				return nil, err
+			}
+		case p.acceptAny("auto_increment", "autoincrement"):
+			col.Identity = true
+		case p.accept("comment"):
+			text, err := p.stringLiteral()
+			if err != nil {
+				return nil, err
+			}
+			col.Comment = text
+		case p.accept("on", "update"):
+			if _, err := p.expression("ON UPDATE"); err != nil {
+				return nil, err
+			}
+		case p.accept("on", "conflict"), p.acceptAny("column_format", "storage", "compression", "srid"):
+			p.i++
+		case p.accept("character", "set"), p.accept("charset"):
+			if _, err := p.ident("character set"); err != nil {
+				return nil, err
+			}
+		case p.accept("deferrable"), p.accept("not", "deferrable"):
+		case p.accept("initially"):
+			p.acceptAny("deferred", "immediate")
+		case p.acceptAny("visible", "invisible"):
+		default:
+			p.warnf(tok, "ignoring %s in definition of column %s", tok.describe(), col.Name)
+			p.skipUntil(p.isColumnKeyword)
+		}
+		name = ""
+	}
+	return constraints, nil
+}
+
+// generated reads the rest of GENERATED {ALWAYS | BY DEFAULT} AS IDENTITY [(options)]
+// or GENERATED ALWAYS AS (expression) [STORED | VIRTUAL]
+func (p *ddlParser) generated(col *types.Column) error {
+	if !p.accept("always") {
+		p.accept("by", "default")
+	}
+	if err := p.expect("as"); err != nil {
+		return err
+	}
+	if p.accept("identity") {
+		col.Identity = true
+		if p.atPunct("(") {
+			_, err := p.group()
+			return err
+		}
+		return nil
+	}
+	if _, err := p.group(); err != nil {
+		return err
+	}
+	col.Generated = true
+	p.acceptAny("stored", "virtual")
+	return nil
+}
+
+// expression reads an expression such as a DEFAULT value up to the next column
+// constraint or the end of the element, and returns its source. It fails when
+// the expression after the clause is missing.
+func (p *ddlParser) expression(clause string) (string, error) {
+	from, tok := p.i, p.peek()
+	p.skipUntil(func() bool { return p.isColumnKeyword() || p.atElementEnd() })
+	if p.i == from {
+		return "", p.errorf(tok, "expected expression after %s, found %s", clause, tok.describe())
+	}
+	return p.text(from, p.i), nil
+}
+
+// acceptNullsDistinct skips the PostgreSQL NULLS [NOT] DISTINCT option of UNIQUE
+func (p *ddlParser) acceptNullsDistinct() {
+	if !p.accept("nulls", "distinct") {
+		p.accept("nulls", "not", "distinct")
+	}
+}
+
+// acceptEnforced skips the MySQL [NOT] ENFORCED option of CHECK
+func (p *ddlParser) acceptEnforced() {
+	if !p.accept("enforced") {
+		p.accept("not", "enforced")
+	}
+}
+
+// quoteLiteral renders a decoded string as a standard SQL literal
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// normalizeDefault cleans up a DEFAULT expression. NULL means no default, and the
+// cast pg_dump adds to literals, as in 'active'::user_status, is dropped.
+func normalizeDefault(expr string) string {
+	expr = strings.TrimSpace(expr)
+	if strings.EqualFold(expr, "null") {
+		return ""
+	}
+	if cast := literalCast(expr); cast != -1 {
+		expr = strings.TrimSpace(expr[:cast])
+		if strings.HasPrefix(expr, "(") {
+			expr = strings.TrimSpace(expr[1 : len(expr)-1])
+		}
+	}
+	return expr
+}
+
+// literalCast returns the offset of the :: cast following a string literal or a
+// (parenthesized) number, or -1 when expr is not a cast literal
+func literalCast(expr string) int {
+	end := 0
+	switch {
+	case strings.HasPrefix(expr, "'"):
+		for end = 1; end < len(expr); end++ {
+			if expr[end] == '\'' {
+				if end+1 < len(expr) && expr[end+1] == '\'' {
+					end++
+					continue
+				}
+				end++
+				break
+			}
+		}
+	case strings.HasPrefix(expr, "("):
+		end = strings.IndexByte(expr, ')') + 1
+		if end == 0 || !isNumber(strings.TrimSpace(expr[1:end-1])) {
+			return -1
+		}
+	default:
+		return -1
+	}
+	rest := strings.TrimSpace(expr[end:])
+	if !strings.HasPrefix(rest, "::") {
+		return -1
+	}
+	// the cast must be the whole remainder, not an operand of a larger expression
+	if strings.ContainsAny(rest[2:], "'+-*/|") {
+		return -1
+	}
+	return strings.Index(expr[end:], "::") + end
+}
+
+func isNumber(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
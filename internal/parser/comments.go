@@ -0,0 +1,62 @@
+package parser
+
+// commentOn parses COMMENT ON TABLE|VIEW|MATERIALIZED VIEW|COLUMN <name> IS '<text>'.
+// IS NULL removes the comment. Comments on other objects are ignored.
+func (p *ddlParser) commentOn() error {
+	if err := p.expect("comment", "on"); err != nil {
+		return err
+	}
+	column := false
+	switch {
+	case p.accept("column"):
+		column = true
+	case p.accept("table"), p.accept("view"), p.accept("materialized", "view"), p.accept("foreign", "table"):
+	default:
+		return nil
+	}
+
+	nameTok := p.peek()
+	parts, err := p.dottedName("name")
+	if err != nil {
+		return err
+	}
+	if err := p.expect("is"); err != nil {
+		return err
+	}
+	text := ""
+	if !p.accept("null") {
+		if text, err = p.stringLiteral(); err != nil {
+			return err
+		}
+	}
+	if err := p.expectEnd(); err != nil {
+		return err
+	}
+
+	if !column {
+		schemaName, tableName := "", parts[len(parts)-1]
+		if len(parts) > 1 {
+			schemaName = parts[len(parts)-2]
+		}
+		if tbl := p.cat.FindTable(schemaName, tableName); tbl != nil {
+			tbl.Comment = text
+		}
+		return nil
+	}
+
+	if len(parts) < 2 {
+		return p.errorf(nameTok, "expected table.column in COMMENT ON COLUMN")
+	}
+	schemaName, tableName := "", parts[len(parts)-2]
+	if len(parts) > 2 {
+		schemaName = parts[len(parts)-3]
+	}
+	tbl := p.cat.FindTable(schemaName, tableName)
+	if tbl == nil {
+		return nil
+	}
+	if col := tbl.FindColumn(parts[len(parts)-1]); col != nil {
+		col.Comment = text
+	}
+	return nil
+}
@@ -0,0 +1,417 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// constraintKind identifies a table constraint
+type constraintKind int
+
+const (
+	constraintCheck constraintKind = iota // recognised but not recorded
+	constraintPrimaryKey
+	constraintUnique
+	constraintForeignKey
+	constraintIndex // MySQL KEY/INDEX line
+)
+
+// tableConstraint is a table-level constraint or MySQL index definition
+type tableConstraint struct {
+	kind    constraintKind
+	name    string
+	columns []string // nil when an index covers expressions
+	fk      *types.ForeignKey
+}
+
+// atTableConstraint reports whether the next table element is a constraint
+// rather than a column definition
+func (p *ddlParser) atTableConstraint() bool {
+	switch {
+	case p.atAny("constraint", "unique", "check", "exclude"),
+		p.at("primary", "key"), p.at("foreign", "key"):
+		return true
+	case p.dialect == types.DialectMySQL:
+		return p.atAny("key", "index", "fulltext", "spatial")
+	}
+	return false
+}
+
+// tableConstraint parses a table constraint:
+//
+//	[CONSTRAINT name] PRIMARY KEY (cols) | UNIQUE [KEY|INDEX] [name] (cols)
+//	| FOREIGN KEY (cols) REFERENCES ... | CHECK (expr) | EXCLUDE ...
+//
+// and the MySQL index lines KEY|INDEX|FULLTEXT|SPATIAL [name] (cols).
+// FULLTEXT and SPATIAL indexes are not equality lookups, and nil is returned for them.
+func (p *ddlParser) tableConstraint() (*tableConstraint, error) {
+	c := &tableConstraint{}
+	if p.accept("constraint") && !p.atAny("primary", "unique", "foreign", "check") {
+		// MySQL allows CONSTRAINT without a name
+		name, err := p.ident("constraint name")
+		if err != nil {
+			return nil, err
+		}
+		c.name = name
+	}
+
+	var err error
+	switch tok := p.peek(); {
+	case p.accept("primary", "key"):
+		c.kind = constraintPrimaryKey
+		if err = p.indexName(c); err == nil {
+			c.columns, err = p.indexColumns()
+		}
+	case p.accept("unique"):
+		c.kind = constraintUnique
+		p.acceptAny("key", "index")
+		p.acceptNullsDistinct()
+		if err = p.indexName(c); err == nil {
+			c.columns, err = p.indexColumns()
+		}
+	case p.accept("foreign", "key"):
+		c.kind = constraintForeignKey
+		if err = p.indexName(c); err == nil {
+			c.columns, err = p.indexColumns()
+		}
+		if err == nil {
+			c.fk, err = p.references()
+		}
+		if err == nil {
+			c.fk.Name = c.name
+			c.fk.Columns = c.columns
+		}
+	case p.accept("check"):
+		c.kind = constraintCheck
+		_, err = p.group()
+		p.accept("no", "inherit")
+		p.acceptEnforced()
+	case p.acceptAny("key", "index"):
+		c.kind = constraintIndex
+		if err = p.indexName(c); err == nil {
+			c.columns, err = p.indexColumns()
+		}
+	case p.acceptAny("fulltext", "spatial"):
+		p.acceptAny("key", "index")
+		p.skipAction()
+		return nil, nil
+	case p.accept("exclude"):
+		p.warnf(tok, "ignoring EXCLUDE constraint")
+		p.skipAction()
+		return nil, nil
+	default:
+		return nil, p.errorf(tok, "expected table constraint, found %s", tok.describe())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := p.constraintOptions(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// indexName reads the optional MySQL index name and USING method before the column list
+func (p *ddlParser) indexName(c *tableConstraint) error {
+	if tok := p.peek(); (tok.kind == tokWord || tok.kind == tokQuoted) && !tok.is("using") {
+		name, err := p.ident("index name")
+		if err != nil {
+			return err
+		}
+		if c.name == "" {
+			c.name = name
+		}
+	}
+	if p.accept("using") {
+		p.i++
+	}
+	return nil
+}
+
+// constraintOptions skips the options following a constraint, such as
+// DEFERRABLE, USING INDEX TABLESPACE or the MySQL COMMENT, reporting unknown ones
+func (p *ddlParser) constraintOptions() error {
+	for !p.atElementEnd() {
+		tok := p.peek()
+		switch {
+		case p.accept("deferrable"), p.accept("not", "deferrable"), p.accept("not", "valid"):
+		case p.accept("initially"):
+			p.acceptAny("deferred", "immediate")
+		case p.accept("using", "index", "tablespace"), p.accept("using"), p.accept("comment"),
+			p.accept("on", "conflict"):
+			p.i++
+		case p.accept("with"), p.accept("include"):
+			if _, err := p.group(); err != nil {
+				return err
+			}
+		case p.accept("key_block_size"):
+			p.acceptOperator("=")
+			p.i++
+		case p.acceptAny("visible", "invisible"):
+		default:
+			p.warnf(tok, "ignoring constraint option %s", tok.describe())
+			p.skipAction()
+		}
+	}
+	return nil
+}
+
+// indexColumns reads the column list of a key or index. ASC/DESC, NULLS FIRST,
+// collations and operator classes only affect ordering and are skipped. It returns
+// nil for lists with expressions or MySQL prefix lengths, which cannot be looked
+// up by column value.
+func (p *ddlParser) indexColumns() ([]string, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	var names []string
+	plain := true
+	for {
+		if tok := p.peek(); tok.kind == tokWord || tok.kind == tokQuoted {
+			names = append(names, tok.value)
+			p.i++
+		} else {
+			plain = false
+		}
+		for depth := 0; ; p.i++ {
+			tok := p.peek()
+			if tok.kind == tokEOF || depth == 0 && (tok.isPunct(",") || tok.isPunct(")")) {
+				break
+			}
+			switch {
+			case tok.isPunct("("):
+				depth++
+				plain = false
+			case tok.isPunct(")"):
+				depth--
+			}
+		}
+		if !p.acceptPunct(",") {
+			break
+		}
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+	if !plain {
+		return nil, nil
+	}
+	return names, nil
+}
+
+// references parses REFERENCES table [(cols)] [MATCH type] [ON DELETE action] [ON UPDATE action]
+func (p *ddlParser) references() (*types.ForeignKey, error) {
+	if err := p.expect("references"); err != nil {
+		return nil, err
+	}
+	refSchema, refTable, err := p.qualifiedName("referenced table")
+	if err != nil {
+		return nil, err
+	}
+
+	fk := &types.ForeignKey{
+		RefSchema: refSchema,
+		RefTable:  refTable,
+	}
+	if p.atPunct("(") {
+		if fk.RefColumns, err = p.indexColumns(); err != nil {
+			return nil, err
+		}
+	}
+	for {
+		switch {
+		case p.accept("match"):
+			p.acceptAny("full", "partial", "simple")
+		case p.accept("on", "delete"):
+			if fk.OnDelete, err = p.referentialAction(); err != nil {
+				return nil, err
+			}
+		case p.accept("on", "update"):
+			if fk.OnUpdate, err = p.referentialAction(); err != nil {
+				return nil, err
+			}
+		default:
+			return fk, nil
+		}
+	}
+}
+
+// referentialAction reads the action following ON DELETE / ON UPDATE
+func (p *ddlParser) referentialAction() (string, error) {
+	switch {
+	case p.accept("cascade"):
+		return "CASCADE", nil
+	case p.accept("restrict"):
+		return "RESTRICT", nil
+	case p.accept("no", "action"):
+		return "NO ACTION", nil
+	case p.accept("set", "null"), p.accept("set", "default"):
+		action := "SET " + strings.ToUpper(p.toks[p.i-1].text)
+		if p.atPunct("(") {
+			// PostgreSQL may limit the action to some columns
+			if _, err := p.group(); err != nil {
+				return "", err
+			}
+		}
+		return action, nil
+	}
+	tok := p.peek()
+	return "", p.errorf(tok, "expected referential action, found %s", tok.describe())
+}
+
+// applyConstraint records a constraint on the table. Unnamed unique constraints
+// get the name the database gives them, so that DROP CONSTRAINT and DROP INDEX
+// can remove them: users_email_key in PostgreSQL, the first column in MySQL.
+func (p *ddlParser) applyConstraint(tbl *types.Table, c *tableConstraint) {
+	if c.kind == constraintUnique && c.name == "" && c.columns != nil {
+		switch p.dialect {
+		case types.DialectPostgres:
+			c.name = tbl.Name + "_" + strings.Join(c.columns, "_") + "_key"
+		case types.DialectMySQL:
+			c.name = c.columns[0]
+		}
+	}
+	applyTableConstraint(tbl, c)
+}
+
+// applyTableConstraint records a table-level constraint. Primary key columns are
+// marked, and a single-column primary key or unique constraint also marks its
+// column unique.
+func applyTableConstraint(tbl *types.Table, c *tableConstraint) {
+	switch c.kind {
+	case constraintForeignKey:
+		tbl.ForeignKeys = append(tbl.ForeignKeys, *c.fk)
+	case constraintPrimaryKey:
+		for _, name := range c.columns {
+			if col := tbl.FindColumn(name); col != nil {
+				col.PrimaryKey = true
+				col.Unique = col.Unique || len(c.columns) == 1
+				col.Nullable = false
+			}
+		}
+	case constraintUnique:
+		if c.columns == nil {
+			return
+		}
+		addIndex(tbl, types.Index{Name: c.name, Columns: c.columns, Unique: true})
+		if len(c.columns) != 1 {
+			return
+		}
+		if col := tbl.FindColumn(c.columns[0]); col != nil {
+			col.Unique = true
+		}
+	case constraintIndex:
+		if c.columns != nil {
+			addIndex(tbl, types.Index{Name: c.name, Columns: c.columns})
+		}
+	}
+}
+
+// parenthesized returns the contents of the first (...) group and the text after it
+func parenthesized(s string) (string, string, bool) {
+	open := strings.Index(s, "(")
+	if open == -1 {
+		return "", s, false
+	}
+	level := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			level++
+		case ')':
+			level--
+			if level == 0 {
+				return s[open+1 : i], s[i+1:], true
+			}
+		}
+	}
+	return "", s, false
+}
+
+// dropColumnReferences removes foreign keys and indexes that depend on a dropped column
+func dropColumnReferences(tbl *types.Table, column string) {
+	kept := tbl.ForeignKeys[:0]
+	for _, fk := range tbl.ForeignKeys {
+		if !containsFold(fk.Columns, column) {
+			kept = append(kept, fk)
+		}
+	}
+	tbl.ForeignKeys = kept
+
+	keptIndexes := tbl.Indexes[:0]
+	for _, idx := range tbl.Indexes {
+		if !containsFold(idx.Columns, column) {
+			keptIndexes = append(keptIndexes, idx)
+		}
+	}
+	tbl.Indexes = keptIndexes
+}
+
+// renameColumnReferences updates foreign keys and indexes after a column rename,
+// both on the table itself and on every table referencing it
+func renameColumnReferences(cat *types.Catalog, tbl *types.Table, oldName, newName string) {
+	for i := range tbl.ForeignKeys {
+		replaceFold(tbl.ForeignKeys[i].Columns, oldName, newName)
+	}
+	for i := range tbl.Indexes {
+		replaceFold(tbl.Indexes[i].Columns, oldName, newName)
+	}
+	for _, other := range cat.Tables {
+		for i := range other.ForeignKeys {
+			fk := &other.ForeignKeys[i]
+			if referencesTable(fk, tbl) {
+				replaceFold(fk.RefColumns, oldName, newName)
+			}
+		}
+	}
+}
+
+// renameTableReferences points foreign keys at a renamed table
+func renameTableReferences(cat *types.Catalog, tbl *types.Table, oldName string) {
+	for _, other := range cat.Tables {
+		for i := range other.ForeignKeys {
+			fk := &other.ForeignKeys[i]
+			if strings.EqualFold(fk.RefTable, oldName) &&
+				(fk.RefSchema == "" || strings.EqualFold(fk.RefSchema, tbl.Schema)) {
+				fk.RefTable = tbl.Name
+			}
+		}
+	}
+}
+
+// dropConstraint removes a named foreign key or unique constraint from the table
+func dropConstraint(tbl *types.Table, name string) {
+	dropIndex(tbl, name)
+
+	kept := tbl.ForeignKeys[:0]
+	for _, fk := range tbl.ForeignKeys {
+		if !strings.EqualFold(fk.Name, name) {
+			kept = append(kept, fk)
+		}
+	}
+	tbl.ForeignKeys = kept
+}
+
+// referencesTable reports whether the foreign key targets the given table
+func referencesTable(fk *types.ForeignKey, tbl *types.Table) bool {
+	return strings.EqualFold(fk.RefTable, tbl.Name) &&
+		(fk.RefSchema == "" || tbl.Schema == "" || strings.EqualFold(fk.RefSchema, tbl.Schema))
+}
+
+func containsFold(list []string, item string) bool {
+	for _, s := range list {
+		if strings.EqualFold(s, item) {
+			return true
+		}
+	}
+	return false
+}
+
+func replaceFold(list []string, oldName, newName string) {
+	for i, s := range list {
+		if strings.EqualFold(s, oldName) {
+			list[i] = newName
+		}
+	}
+}
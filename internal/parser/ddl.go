@@ -0,0 +1,452 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// ddlParser is a recursive-descent parser over the tokens of one statement.
+// Syntax errors are returned as positioned diagnostics. Clauses that cannot be
+// represented in the catalog are skipped and recorded as warnings.
+type ddlParser struct {
+	cat     *types.Catalog
+	file    string
+	st      statement
+	toks    []token
+	i       int
+	dialect types.Dialect
+}
+
+// newDDLParser creates a parser for a statement of file, applying it to cat
+func newDDLParser(cat *types.Catalog, file string, st statement, dialect types.Dialect) *ddlParser {
+	return &ddlParser{cat: cat, file: file, st: st, toks: st.Tokens, dialect: dialect}
+}
+
+// sub returns a parser over toks, a part of the statement such as the type of a cast
+func (p *ddlParser) sub(toks []token) *ddlParser {
+	return &ddlParser{cat: p.cat, file: p.file, st: p.st, toks: toks, dialect: p.dialect}
+}
+
+// peek returns the current token without consuming it
+func (p *ddlParser) peek() token {
+	return p.peekAt(0)
+}
+
+// peekAt returns the token n positions ahead, or an EOF token past the end of the statement
+func (p *ddlParser) peekAt(n int) token {
+	if p.i+n < len(p.toks) {
+		return p.toks[p.i+n]
+	}
+	eof := token{kind: tokEOF, pos: p.st.Pos}
+	if len(p.toks) > 0 {
+		last := p.toks[len(p.toks)-1]
+		eof.pos = last.pos
+		if nl := strings.LastIndexByte(last.text, '\n'); nl != -1 {
+			eof.pos.Line += strings.Count(last.text, "\n")
+			eof.pos.Col = 1 + utf8.RuneCountInString(last.text[nl+1:])
+		} else {
+			eof.pos.Col += utf8.RuneCountInString(last.text)
+		}
+		eof.start, eof.end = last.end, last.end
+	}
+	return eof
+}
+
+// next consumes and returns the current token
+func (p *ddlParser) next() token {
+	tok := p.peek()
+	if p.i < len(p.toks) {
+		p.i++
+	}
+	return tok
+}
+
+func (p *ddlParser) atEOF() bool {
+	return p.i >= len(p.toks)
+}
+
+// at reports whether the next tokens are the given keywords
+func (p *ddlParser) at(keywords ...string) bool {
+	for n, kw := range keywords {
+		if !p.peekAt(n).is(kw) {
+			return false
+		}
+	}
+	return true
+}
+
+// atAny reports whether the current token is one of the given keywords
+func (p *ddlParser) atAny(keywords ...string) bool {
+	for _, kw := range keywords {
+		if p.peek().is(kw) {
+			return true
+		}
+	}
+	return false
+}
+
+// accept consumes the given keyword sequence if it comes next
+func (p *ddlParser) accept(keywords ...string) bool {
+	if !p.at(keywords...) {
+		return false
+	}
+	p.i += len(keywords)
+	return true
+}
+
+// acceptAny consumes the current token if it is one of the given keywords
+func (p *ddlParser) acceptAny(keywords ...string) bool {
+	if !p.atAny(keywords...) {
+		return false
+	}
+	p.i++
+	return true
+}
+
+// expect consumes the given keyword sequence or fails at the first mismatch
+func (p *ddlParser) expect(keywords ...string) error {
+	for _, kw := range keywords {
+		if tok := p.peek(); !tok.is(kw) {
+			return p.errorf(tok, "expected %s, found %s", strings.ToUpper(kw), tok.describe())
+		}
+		p.i++
+	}
+	return nil
+}
+
+func (p *ddlParser) atPunct(s string) bool {
+	return p.peek().isPunct(s)
+}
+
+func (p *ddlParser) acceptPunct(s string) bool {
+	if !p.atPunct(s) {
+		return false
+	}
+	p.i++
+	return true
+}
+
+func (p *ddlParser) expectPunct(s string) error {
+	if tok := p.peek(); !tok.isPunct(s) {
+		return p.errorf(tok, "expected %q, found %s", s, tok.describe())
+	}
+	p.i++
+	return nil
+}
+
+// acceptOperator consumes the given operator, e.g. the optional "=" of MySQL options
+func (p *ddlParser) acceptOperator(op string) bool {
+	if tok := p.peek(); tok.kind != tokOperator || tok.text != op {
+		return false
+	}
+	p.i++
+	return true
+}
+
+// errorf returns a diagnostic positioned at tok
+func (p *ddlParser) errorf(tok token, format string, args ...interface{}) error {
+	return &types.Diagnostic{File: p.file, Line: tok.pos.Line, Col: tok.pos.Col, Message: fmt.Sprintf(format, args...)}
+}
+
+// warnf records a warning positioned at tok
+func (p *ddlParser) warnf(tok token, format string, args ...interface{}) {
+	if p.cat == nil {
+		return
+	}
+	p.cat.Warnings = append(p.cat.Warnings, types.Diagnostic{
+		File:    p.file,
+		Line:    tok.pos.Line,
+		Col:     tok.pos.Col,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// ident reads a bare or quoted identifier
+func (p *ddlParser) ident(what string) (string, error) {
+	tok := p.peek()
+	if tok.kind != tokWord && tok.kind != tokQuoted && !p.isDoubleQuoted(tok) {
+		return "", p.errorf(tok, "expected %s, found %s", what, tok.describe())
+	}
+	p.i++
+	return tok.value, nil
+}
+
+// dottedName reads a name of one or more identifiers separated by dots
+func (p *ddlParser) dottedName(what string) ([]string, error) {
+	var parts []string
+	for {
+		part, err := p.ident(what)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+		if !p.atPunct(".") {
+			return parts, nil
+		}
+		p.i++
+	}
+}
+
+// qualifiedName reads [catalog.][schema.]name and returns the schema and name
+func (p *ddlParser) qualifiedName(what string) (string, string, error) {
+	parts, err := p.dottedName(what)
+	if err != nil {
+		return "", "", err
+	}
+	if len(parts) == 1 {
+		return "", parts[0], nil
+	}
+	return parts[len(parts)-2], parts[len(parts)-1], nil
+}
+
+// identList reads a parenthesized list of identifiers, e.g. the column names of a view
+func (p *ddlParser) identList(what string) ([]string, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	var names []string
+	for {
+		name, err := p.ident(what)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+		if !p.acceptPunct(",") {
+			return names, p.expectPunct(")")
+		}
+	}
+}
+
+// isDoubleQuoted reports whether tok is a MySQL "double quoted" string, which
+// names an identifier when the server runs with ANSI_QUOTES
+func (p *ddlParser) isDoubleQuoted(tok token) bool {
+	return tok.kind == tokString && tok.text[0] == '"'
+}
+
+// stringLiteral reads a string literal
+func (p *ddlParser) stringLiteral() (string, error) {
+	tok := p.peek()
+	if tok.kind == tokString {
+		p.i++
+		return tok.value, nil
+	}
+	return "", p.errorf(tok, "expected string literal, found %s", tok.describe())
+}
+
+// text returns the source of the tokens from index from up to, but excluding, to
+func (p *ddlParser) text(from, to int) string {
+	if from >= to {
+		return ""
+	}
+	return strings.TrimSpace(p.st.src[p.toks[from].start:p.toks[to-1].end])
+}
+
+// group reads a balanced (...) group and returns the source between the parentheses
+func (p *ddlParser) group() (string, error) {
+	open := p.peek()
+	if !open.isPunct("(") {
+		return "", p.errorf(open, "expected \"(\", found %s", open.describe())
+	}
+	p.i++
+	from, depth := p.i, 1
+	for {
+		tok := p.next()
+		switch {
+		case tok.kind == tokEOF:
+			return "", p.errorf(open, "unbalanced parenthesis")
+		case tok.isPunct("("):
+			depth++
+		case tok.isPunct(")"):
+			if depth--; depth == 0 {
+				return p.text(from, p.i-1), nil
+			}
+		}
+	}
+}
+
+// skipUntil skips tokens up to the end of the current list element: a "," or ")"
+// at the top level, the end of the statement, or a top-level token matching stop.
+// At least one token is skipped.
+func (p *ddlParser) skipUntil(stop func() bool) {
+	depth := 0
+	for first := true; !p.atEOF(); first = false {
+		tok := p.peek()
+		if depth == 0 && (tok.isPunct(",") || tok.isPunct(")") || !first && stop != nil && stop()) {
+			return
+		}
+		switch {
+		case tok.isPunct("("):
+			depth++
+		case tok.isPunct(")"):
+			depth--
+		}
+		p.i++
+	}
+}
+
+// skipAction skips the rest of an ALTER TABLE action or table element
+func (p *ddlParser) skipAction() {
+	p.skipUntil(nil)
+}
+
+// expectEnd fails when tokens are left over after a complete statement
+func (p *ddlParser) expectEnd() error {
+	if tok := p.peek(); tok.kind != tokEOF {
+		return p.errorf(tok, "unexpected %s", tok.describe())
+	}
+	return nil
+}
+
+// tableModifiers may come between CREATE [OR REPLACE] and TABLE
+var tableModifiers = []string{"global", "local", "temp", "temporary", "unlogged"}
+
+// atCreate reports whether the statement is CREATE [OR REPLACE], any of the
+// modifiers, then the object keyword, e.g. CREATE TEMP TABLE
+func (p *ddlParser) atCreate(modifiers []string, object string) bool {
+	if !p.at("create") {
+		return false
+	}
+	n := 1
+	if p.peekAt(n).is("or") && p.peekAt(n+1).is("replace") {
+		n += 2
+	}
+	for p.peekAt(n).kind == tokWord && containsFold(modifiers, p.peekAt(n).text) {
+		n++
+	}
+	return p.peekAt(n).is(object)
+}
+
+// createTable parses CREATE [TEMP|UNLOGGED] TABLE [IF NOT EXISTS] name (elements) [options].
+// Tables declared AS a query, LIKE or as a partition of another table have no
+// column list; they are reported and nil is returned.
+func (p *ddlParser) createTable() (*types.Table, error) {
+	if err := p.expect("create"); err != nil {
+		return nil, err
+	}
+	p.accept("or", "replace")
+	for p.acceptAny(tableModifiers...) {
+	}
+	if err := p.expect("table"); err != nil {
+		return nil, err
+	}
+	p.accept("if", "not", "exists")
+
+	schemaName, tableName, err := p.qualifiedName("table name")
+	if err != nil {
+		return nil, err
+	}
+	if p.dialect == types.DialectSQLite {
+		schemaName = sqliteSchema(schemaName)
+	}
+
+	if tok := p.peek(); tok.is("as") || tok.is("like") || tok.is("partition") || tok.is("of") {
+		p.warnf(tok, "ignoring CREATE TABLE %s %s ...: only tables with a column list are supported", tableName, strings.ToUpper(tok.text))
+		return nil, nil
+	}
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+
+	tbl := newTable(schemaName, tableName)
+	var constraints []*tableConstraint
+	for !p.atPunct(")") {
+		switch tok := p.peek(); {
+		case p.atTableConstraint():
+			c, err := p.tableConstraint()
+			if err != nil {
+				return nil, err
+			}
+			if c != nil {
+				constraints = append(constraints, c)
+			}
+		case tok.is("like"):
+			p.warnf(tok, "ignoring LIKE in CREATE TABLE %s: columns of the source table are not copied", tableName)
+			p.skipAction()
+		default:
+			col, inline, err := p.columnDef()
+			if err != nil {
+				return nil, err
+			}
+			if tbl.FindColumn(col.Name) != nil {
+				return nil, p.errorf(tok, "column %q specified more than once", col.Name)
+			}
+			tbl.Columns = append(tbl.Columns, col)
+			for _, c := range inline {
+				p.applyConstraint(tbl, c)
+			}
+		}
+		if !p.acceptPunct(",") {
+			break
+		}
+		if tok := p.peek(); tok.isPunct(")") {
+			return nil, p.errorf(tok, "expected column or constraint definition after \",\", found %s", tok.describe())
+		}
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+
+	// table constraints may name any column, so they are applied last
+	for _, c := range constraints {
+		p.applyConstraint(tbl, c)
+	}
+	if err := p.tableOptions(tbl); err != nil {
+		return nil, err
+	}
+	if p.dialect == types.DialectSQLite {
+		setRowIDAlias(tbl)
+	}
+	return tbl, nil
+}
+
+// tableOptions reads the options after the column list. The MySQL COMMENT and
+// SQLite WITHOUT ROWID are recorded and storage options are skipped. Options that
+// change the shape of the table, such as INHERITS, are reported.
+func (p *ddlParser) tableOptions(tbl *types.Table) error {
+	for !p.atEOF() {
+		tok := p.peek()
+		switch {
+		case p.acceptPunct(","):
+		case p.accept("without", "rowid"):
+			tbl.WithoutRowID = true
+		case p.accept("strict"), p.accept("without", "oids"):
+		case p.accept("comment"):
+			p.acceptOperator("=")
+			text, err := p.stringLiteral()
+			if err != nil {
+				return err
+			}
+			tbl.Comment = text
+		case p.accept("inherits"):
+			if _, err := p.group(); err != nil {
+				return err
+			}
+			p.warnf(tok, "ignoring INHERITS: inherited columns are not added to %s", tbl.Name)
+		case p.accept("partition", "by"):
+			// partitioning does not change the columns
+			p.i = len(p.toks)
+		case p.accept("with"):
+			if _, err := p.group(); err != nil {
+				return err
+			}
+		case p.accept("on", "commit"):
+			p.i++
+			p.accept("rows")
+		case p.accept("tablespace"), p.accept("using"):
+			if _, err := p.ident("name"); err != nil {
+				return err
+			}
+		case p.dialect == types.DialectMySQL && tok.kind == tokWord:
+			if err := p.mysqlTableOption(); err != nil {
+				return err
+			}
+		default:
+			p.warnf(tok, "ignoring table option %s and the rest of CREATE TABLE %s", tok.describe(), tbl.Name)
+			p.i = len(p.toks)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,485 @@
+package parser
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// parseSQL applies the statements of sql to a new catalog, as a migration file would
+func parseSQL(t *testing.T, sql string, dialect types.Dialect) (*types.Catalog, error) {
+	t.Helper()
+	cat := &types.Catalog{}
+	stmts, err := splitStatements(sql, dialect)
+	if err != nil {
+		return nil, diagnostic("test.sql", position{}, err)
+	}
+	for _, st := range stmts {
+		if err := applyStatement(cat, "test.sql", st, dialect); err != nil {
+			return nil, diagnostic("test.sql", st.Pos, err)
+		}
+	}
+	resolveEnums(cat)
+	return cat, nil
+}
+
+// mustTable parses sql and returns schema.name
+func mustTable(t *testing.T, sql string, dialect types.Dialect, schema, name string) *types.Table {
+	t.Helper()
+	cat, err := parseSQL(t, sql, dialect)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	tbl := cat.FindTable(schema, name)
+	if tbl == nil {
+		t.Fatalf("table %s.%s not found", schema, name)
+	}
+	return tbl
+}
+
+// mustColumn returns the named column of tbl
+func mustColumn(t *testing.T, tbl *types.Table, name string) types.Column {
+	t.Helper()
+	col := tbl.FindColumn(name)
+	if col == nil {
+		t.Fatalf("column %s.%s not found", tbl.Name, name)
+	}
+	return *col
+}
+
+func columnNames(tbl *types.Table) []string {
+	var names []string
+	for _, col := range tbl.Columns {
+		names = append(names, col.Name)
+	}
+	return names
+}
+
+func TestCreateTableColumns(t *testing.T) {
+	tbl := mustTable(t, `
+CREATE TABLE IF NOT EXISTS auth.users (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    email VARCHAR(255) NOT NULL UNIQUE,
+    status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'suspended')),
+    balance NUMERIC(12, 2),
+    seq BIGSERIAL,
+    note "nullable_text",
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);`, types.DialectPostgres, "auth", "users")
+
+	tests := []struct {
+		name string
+		want types.Column
+	}{
+		{"id", types.Column{Name: "id", Type: "UUID", PrimaryKey: true, Unique: true, Default: "gen_random_uuid()"}},
+		{"email", types.Column{Name: "email", Type: "VARCHAR(255)", Unique: true, Length: 255}},
+		{"status", types.Column{Name: "status", Type: "TEXT", Default: "'active'", Check: "status IN ('active', 'suspended')"}},
+		{"balance", types.Column{Name: "balance", Type: "NUMERIC(12,2)", Nullable: true, Precision: 12, Scale: 2}},
+		{"seq", types.Column{Name: "seq", Type: "BIGSERIAL", Identity: true}},
+		{"note", types.Column{Name: "note", Type: "nullable_text", Nullable: true}},
+		{"created_at", types.Column{Name: "created_at", Type: "TIMESTAMPTZ", Default: "now()"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mustColumn(t, tbl, tt.name); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("column = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateTableConstraints(t *testing.T) {
+	tbl := mustTable(t, `
+CREATE TABLE auth.user_roles (
+    user_id UUID NOT NULL REFERENCES auth.users (id) ON DELETE CASCADE,
+    role_id UUID NOT NULL,
+    org_unit_id UUID NOT NULL,
+    CONSTRAINT user_roles_pkey PRIMARY KEY (user_id, role_id),
+    CONSTRAINT user_roles_role_fk FOREIGN KEY (role_id) REFERENCES auth.roles (id),
+    UNIQUE (org_unit_id, role_id)
+);`, types.DialectPostgres, "auth", "user_roles")
+
+	var pk []string
+	for _, col := range tbl.Columns {
+		if col.PrimaryKey {
+			pk = append(pk, col.Name)
+		}
+	}
+	if want := []string{"user_id", "role_id"}; !reflect.DeepEqual(pk, want) {
+		t.Errorf("primary key = %q, want %q", pk, want)
+	}
+
+	wantFKs := []types.ForeignKey{
+		{Columns: []string{"user_id"}, RefSchema: "auth", RefTable: "users", RefColumns: []string{"id"}, OnDelete: "CASCADE"},
+		{Name: "user_roles_role_fk", Columns: []string{"role_id"}, RefSchema: "auth", RefTable: "roles", RefColumns: []string{"id"}},
+	}
+	if !reflect.DeepEqual(tbl.ForeignKeys, wantFKs) {
+		t.Errorf("foreign keys = %+v, want %+v", tbl.ForeignKeys, wantFKs)
+	}
+
+	// unnamed unique constraints get the name PostgreSQL gives them
+	wantIndexes := []types.Index{{Name: "user_roles_org_unit_id_role_id_key", Columns: []string{"org_unit_id", "role_id"}, Unique: true}}
+	if !reflect.DeepEqual(tbl.Indexes, wantIndexes) {
+		t.Errorf("indexes = %+v, want %+v", tbl.Indexes, wantIndexes)
+	}
+}
+
+func TestAlterTableReplay(t *testing.T) {
+	tbl := mustTable(t, `
+CREATE TABLE users (id UUID PRIMARY KEY, name TEXT, legacy TEXT, age INT);
+ALTER TABLE users ADD COLUMN email VARCHAR(100) NOT NULL;
+ALTER TABLE users DROP COLUMN legacy;
+ALTER TABLE users RENAME COLUMN name TO full_name;
+ALTER TABLE users ALTER COLUMN age TYPE BIGINT, ALTER COLUMN age SET NOT NULL;
+ALTER TABLE users ALTER COLUMN full_name SET DEFAULT 'anonymous';
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+ALTER TABLE users RENAME TO members;
+`, types.DialectPostgres, "public", "members")
+
+	if want := []string{"id", "full_name", "age", "email"}; !reflect.DeepEqual(columnNames(tbl), want) {
+		t.Errorf("columns = %q, want %q", columnNames(tbl), want)
+	}
+	if age := mustColumn(t, tbl, "age"); age.Type != "BIGINT" || age.Nullable {
+		t.Errorf("age = %+v, want a NOT NULL BIGINT", age)
+	}
+	if name := mustColumn(t, tbl, "full_name"); name.Default != "'anonymous'" {
+		t.Errorf("full_name default = %q, want 'anonymous'", name.Default)
+	}
+	if email := mustColumn(t, tbl, "email"); email.Nullable || email.Length != 100 {
+		t.Errorf("email = %+v, want a NOT NULL VARCHAR(100)", email)
+	}
+	wantIndexes := []types.Index{{Name: "users_email_key", Columns: []string{"email"}, Unique: true}}
+	if !reflect.DeepEqual(tbl.Indexes, wantIndexes) {
+		t.Errorf("indexes = %+v, want %+v", tbl.Indexes, wantIndexes)
+	}
+}
+
+func TestAlterTableDropAndRecreate(t *testing.T) {
+	cat, err := parseSQL(t, `
+CREATE TABLE a (id INT);
+CREATE TABLE b (id INT);
+DROP TABLE IF EXISTS a;
+ALTER TABLE b ALTER COLUMN id DROP NOT NULL;
+ALTER TABLE IF EXISTS missing ADD COLUMN x INT;
+`, types.DialectPostgres)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if cat.FindTable("public", "a") != nil {
+		t.Error("dropped table a is still in the catalog")
+	}
+	if cat.FindTable("public", "b") == nil {
+		t.Error("table b is missing")
+	}
+	if len(cat.Warnings) != 0 {
+		t.Errorf("warnings = %v, want none for ALTER TABLE IF EXISTS", cat.Warnings)
+	}
+}
+
+func TestAlterMissingTable(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		warnings []string
+	}{
+		{
+			name: "typo in the table name",
+			files: map[string]string{
+				"000001_users.up.sql": "CREATE TABLE users (id INT);",
+				"000002_email.up.sql": "ALTER TABLE usres ADD COLUMN email TEXT;",
+			},
+			warnings: []string{"000002_email.up.sql:1:13: ignoring ALTER TABLE usres: table is not created by an earlier migration"},
+		},
+		{
+			name: "altered before it is created",
+			files: map[string]string{
+				"000001_email.up.sql": "ALTER TABLE users ADD COLUMN email TEXT;",
+				"000002_users.up.sql": "CREATE TABLE users (id INT);",
+			},
+			warnings: []string{"000001_email.up.sql:1:13: ignoring ALTER TABLE users: table is not created by an earlier migration"},
+		},
+		{
+			name: "if exists",
+			files: map[string]string{
+				"000001_users.up.sql": "CREATE TABLE users (id INT);",
+				"000002_email.up.sql": "ALTER TABLE IF EXISTS usres ADD COLUMN email TEXT;",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+			cat, err := LoadCatalog(dir, types.DialectPostgres, golangMigrateReader{})
+			if err != nil {
+				t.Fatalf("LoadCatalog() error = %v", err)
+			}
+
+			var got []string
+			for _, w := range cat.Warnings {
+				got = append(got, strings.TrimPrefix(w.String(), dir+string(filepath.Separator)))
+			}
+			if !reflect.DeepEqual(got, tt.warnings) {
+				t.Errorf("warnings = %q, want %q", got, tt.warnings)
+			}
+			if names := columnNames(cat.FindTable("public", "users")); !reflect.DeepEqual(names, []string{"id"}) {
+				t.Errorf("users columns = %q, want [id]", names)
+			}
+		})
+	}
+}
+
+func TestEnumReplay(t *testing.T) {
+	cat, err := parseSQL(t, `
+CREATE TYPE auth.user_status AS ENUM ('active', 'banned');
+ALTER TYPE auth.user_status ADD VALUE 'pending' BEFORE 'active';
+ALTER TYPE auth.user_status RENAME VALUE 'banned' TO 'suspended';
+CREATE TABLE auth.users (id UUID PRIMARY KEY, status auth.user_status NOT NULL);
+`, types.DialectPostgres)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	status := mustColumn(t, cat.FindTable("auth", "users"), "status")
+	if status.Enum == nil {
+		t.Fatal("status has no enum")
+	}
+	if want := []string{"pending", "active", "suspended"}; !reflect.DeepEqual(status.Enum.Values, want) {
+		t.Errorf("enum values = %q, want %q", status.Enum.Values, want)
+	}
+}
+
+func TestQuotedIdentifierReplay(t *testing.T) {
+	cat, err := parseSQL(t, `
+CREATE TABLE "Order Items" (id INT PRIMARY KEY, "Code" TEXT, note TEXT);
+CREATE TABLE "Drop, Me" (id INT);
+CREATE UNIQUE INDEX "Code Idx" ON "Order Items" ("Code");
+CREATE INDEX "Note Idx" ON "Order Items" (note);
+ALTER INDEX "Code Idx" RENAME TO "code idx";
+DROP INDEX IF EXISTS "Note Idx", missing_idx CASCADE;
+CREATE TYPE "Mood" AS ENUM ('happy', 'it''s fine');
+ALTER TYPE "Mood" ADD VALUE IF NOT EXISTS 'sad' AFTER 'happy';
+CREATE TYPE gone AS ENUM ();
+DROP TYPE IF EXISTS gone, "Other";
+CREATE VIEW "Item View" AS SELECT "i"."Code" FROM "Order Items" AS "i";
+DROP TABLE "Drop, Me";
+`, types.DialectPostgres)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	items := cat.FindTable("", "Order Items")
+	if items == nil {
+		t.Fatal(`table "Order Items" not found`)
+	}
+	wantIndexes := []types.Index{{Name: "code idx", Columns: []string{"Code"}, Unique: true}}
+	if !reflect.DeepEqual(items.Indexes, wantIndexes) {
+		t.Errorf("indexes = %+v, want %+v", items.Indexes, wantIndexes)
+	}
+	if cat.FindTable("", "Drop, Me") != nil {
+		t.Error(`dropped table "Drop, Me" is still in the catalog`)
+	}
+	if cat.FindEnum("", "gone") != nil {
+		t.Error("dropped enum gone is still in the catalog")
+	}
+	mood := cat.FindEnum("", "Mood")
+	if mood == nil {
+		t.Fatal(`enum "Mood" not found`)
+	}
+	if want := []string{"happy", "sad", "it's fine"}; !reflect.DeepEqual(mood.Values, want) {
+		t.Errorf("enum values = %q, want %q", mood.Values, want)
+	}
+	view := cat.FindTable("", "Item View")
+	if view == nil {
+		t.Fatal(`view "Item View" not found`)
+	}
+	if code := mustColumn(t, view, "Code"); code.Type != "TEXT" {
+		t.Errorf("view column Code = %+v, want the TEXT column of the table", code)
+	}
+}
+
+func TestCreateIndex(t *testing.T) {
+	tbl := mustTable(t, `
+CREATE TABLE posts (id UUID PRIMARY KEY, org_unit_id UUID NOT NULL, slug TEXT NOT NULL, author_id UUID);
+CREATE UNIQUE INDEX posts_slug_idx ON posts (org_unit_id, slug);
+CREATE INDEX CONCURRENTLY IF NOT EXISTS posts_author_idx ON posts USING btree (author_id);
+CREATE INDEX posts_lower_slug_idx ON posts (lower(slug));
+`, types.DialectPostgres, "public", "posts")
+
+	want := []types.Index{
+		{Name: "posts_slug_idx", Columns: []string{"org_unit_id", "slug"}, Unique: true},
+		{Name: "posts_author_idx", Columns: []string{"author_id"}},
+	}
+	if !reflect.DeepEqual(tbl.Indexes, want) {
+		t.Errorf("indexes = %+v, want %+v", tbl.Indexes, want)
+	}
+}
+
+func TestMultipleTablesPerFile(t *testing.T) {
+	cat, err := parseSQL(t, `
+CREATE TABLE a (id INT PRIMARY KEY, note TEXT DEFAULT 'x;y');
+CREATE TABLE b (id INT PRIMARY KEY);
+COMMENT ON TABLE a IS 'Holds a; and b';
+`, types.DialectPostgres)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if len(cat.Tables) != 2 {
+		t.Fatalf("tables = %d, want 2", len(cat.Tables))
+	}
+	if got := cat.FindTable("public", "a").Comment; got != "Holds a; and b" {
+		t.Errorf("comment = %q", got)
+	}
+}
+
+func TestDialects(t *testing.T) {
+	t.Run("mysql", func(t *testing.T) {
+		tbl := mustTable(t, "CREATE TABLE `orders` (\n"+
+			"  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,\n"+
+			"  `status` ENUM('new','paid') NOT NULL DEFAULT 'new',\n"+
+			"  `total` DECIMAL(10,2) COMMENT 'gross',\n"+
+			"  PRIMARY KEY (`id`),\n"+
+			"  KEY `orders_status_idx` (`status`)\n"+
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='Customer orders';", types.DialectMySQL, "shop", "orders")
+
+		id := mustColumn(t, tbl, "id")
+		if !id.PrimaryKey || !id.Identity || !id.Unsigned {
+			t.Errorf("id = %+v, want an unsigned auto increment primary key", id)
+		}
+		status := mustColumn(t, tbl, "status")
+		if status.Enum == nil || !reflect.DeepEqual(status.Enum.Values, []string{"new", "paid"}) {
+			t.Errorf("status enum = %+v", status.Enum)
+		}
+		if total := mustColumn(t, tbl, "total"); total.Comment != "gross" {
+			t.Errorf("total comment = %q", total.Comment)
+		}
+		if tbl.Comment != "Customer orders" {
+			t.Errorf("table comment = %q", tbl.Comment)
+		}
+	})
+
+	t.Run("sqlite", func(t *testing.T) {
+		tbl := mustTable(t, `CREATE TABLE notes (id INTEGER PRIMARY KEY, [body text] TEXT NOT NULL) WITHOUT ROWID;`,
+			types.DialectSQLite, "main", "notes")
+		if !tbl.WithoutRowID {
+			t.Error("WITHOUT ROWID was not recorded")
+		}
+		if want := []string{"id", "body text"}; !reflect.DeepEqual(columnNames(tbl), want) {
+			t.Errorf("columns = %q, want %q", columnNames(tbl), want)
+		}
+	})
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want string
+	}{
+		{
+			name: "trailing comma in the column list",
+			sql:  "CREATE TABLE t (a int, b text,)",
+			want: `test.sql:1:31: expected column or constraint definition after ",", found ")"`,
+		},
+		{
+			name: "empty DEFAULT expression",
+			sql:  "CREATE TABLE t (\n  a int,\n  name text NOT NULL DEFAULT,\n  b int\n)",
+			want: `test.sql:3:29: expected expression after DEFAULT, found ","`,
+		},
+		{
+			name: "empty SET DEFAULT expression",
+			sql:  "CREATE TABLE t (a int);\nALTER TABLE t ALTER COLUMN a SET DEFAULT;",
+			want: "test.sql:2:41: expected expression after SET DEFAULT, found end of statement",
+		},
+		{
+			name: "missing closing parenthesis",
+			sql:  "CREATE TABLE t (a int",
+			want: `test.sql:1:22: expected ")", found end of statement`,
+		},
+		{
+			name: "end of statement after multibyte characters",
+			sql:  "CREATE TABLE t (a text DEFAULT 'é'",
+			want: `test.sql:1:35: expected ")", found end of statement`,
+		},
+		{
+			name: "missing name in DROP TABLE",
+			sql:  "CREATE TABLE t (a int);\nDROP TABLE t,;",
+			want: "test.sql:2:14: expected table name, found end of statement",
+		},
+		{
+			name: "enum value that is not a string",
+			sql:  "CREATE TYPE mood AS ENUM ('happy',\n  sad)",
+			want: `test.sql:2:3: expected string literal, found "sad"`,
+		},
+		{
+			name: "missing table in DROP INDEX",
+			sql:  "DROP INDEX idx ON ;",
+			want: "test.sql:1:18: expected table name, found end of statement",
+		},
+		{
+			name: "view without a query",
+			sql:  "CREATE VIEW v AS VALUES (1)",
+			want: `test.sql:1:18: view v: expected SELECT, found "VALUES"`,
+		},
+		{
+			name: "duplicate column",
+			sql:  "CREATE TABLE t (a int,\n  a text)",
+			want: `test.sql:2:3: column "a" specified more than once`,
+		},
+		{
+			name: "altering a missing column",
+			sql:  "CREATE TABLE t (a int);\nALTER TABLE t ALTER COLUMN b SET NOT NULL;",
+			want: `test.sql:2:28: column "b" of t does not exist`,
+		},
+		{
+			name: "unterminated string",
+			sql:  "CREATE TABLE t (\n  a text DEFAULT 'x\n)",
+			want: "test.sql:2:18: unterminated string literal",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSQL(t, tt.sql, types.DialectPostgres)
+			if err == nil {
+				t.Fatal("parse error = nil")
+			}
+			if err.Error() != tt.want {
+				t.Errorf("parse error = %q, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWarnings(t *testing.T) {
+	cat, err := parseSQL(t, `SET statement_timeout = 0;
+BEGIN;
+CREATE SCHEMA IF NOT EXISTS auth;
+CREATE EXTENSION IF NOT EXISTS citext;
+CREATE TABLE base (id INT);
+CREATE TABLE child (extra INT) INHERITS (base);
+CREATE OR REPLACE FUNCTION touch() RETURNS trigger AS $$ BEGIN RETURN NEW; END; $$ LANGUAGE plpgsql;
+INSERT INTO base (id) VALUES (1);
+COMMIT;
+`, types.DialectPostgres)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	var got []string
+	for _, w := range cat.Warnings {
+		got = append(got, w.String())
+	}
+	want := []string{
+		"test.sql:4:1: ignoring CREATE EXTENSION statement: only tables, views, indexes, enums, sequences and comments are read",
+		"test.sql:6:32: ignoring INHERITS: inherited columns are not added to child",
+		"test.sql:7:1: ignoring CREATE FUNCTION statement: only tables, views, indexes, enums, sequences and comments are read",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("warnings =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
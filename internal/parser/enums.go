@@ -0,0 +1,204 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/rifqiakrm/starter-cli/internal/inflection"
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// atCreateEnum reports whether the statement is CREATE TYPE name AS ENUM
+func (p *ddlParser) atCreateEnum() bool {
+	if !p.at("create", "type") {
+		return false
+	}
+	n := 3
+	for p.peekAt(n).isPunct(".") {
+		n += 2
+	}
+	return p.peekAt(n).is("as") && p.peekAt(n+1).is("enum")
+}
+
+// createEnum parses CREATE TYPE name AS ENUM ('a', 'b')
+func (p *ddlParser) createEnum() (*types.Enum, error) {
+	if err := p.expect("create", "type"); err != nil {
+		return nil, err
+	}
+	schemaName, enumName, err := p.qualifiedName("type name")
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect("as", "enum"); err != nil {
+		return nil, err
+	}
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+
+	enum := &types.Enum{Schema: schemaName, Name: enumName}
+	for !p.atPunct(")") {
+		value, err := p.stringLiteral()
+		if err != nil {
+			return nil, err
+		}
+		enum.Values = append(enum.Values, value)
+		if !p.acceptPunct(",") {
+			break
+		}
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+	return enum, p.expectEnd()
+}
+
+// alterType handles ADD VALUE, RENAME VALUE, RENAME TO and SET SCHEMA on enum
+// types. Other types, such as composite and domain types, are not tracked.
+func (p *ddlParser) alterType() error {
+	if err := p.expect("alter", "type"); err != nil {
+		return err
+	}
+	schemaName, enumName, err := p.qualifiedName("type name")
+	if err != nil {
+		return err
+	}
+	enum := p.cat.FindEnum(schemaName, enumName)
+	if enum == nil {
+		return nil
+	}
+
+	switch {
+	case p.accept("add", "value"):
+		p.accept("if", "not", "exists")
+		value, err := p.stringLiteral()
+		if err != nil {
+			return err
+		}
+		values := []string{value}
+		before := p.accept("before")
+		if before || p.accept("after") {
+			neighbor, err := p.stringLiteral()
+			if err != nil {
+				return err
+			}
+			values = append(values, neighbor)
+		}
+		addEnumValue(enum, values, before)
+	case p.accept("rename", "value"):
+		oldValue, err := p.stringLiteral()
+		if err != nil {
+			return err
+		}
+		if err := p.expect("to"); err != nil {
+			return err
+		}
+		newValue, err := p.stringLiteral()
+		if err != nil {
+			return err
+		}
+		for i, v := range enum.Values {
+			if v == oldValue {
+				enum.Values[i] = newValue
+			}
+		}
+	case p.accept("rename", "to"):
+		if enum.Name, err = p.ident("type name"); err != nil {
+			return err
+		}
+	case p.accept("set", "schema"):
+		if enum.Schema, err = p.ident("schema name"); err != nil {
+			return err
+		}
+	default:
+		// OWNER TO does not change the values
+		return nil
+	}
+	return p.expectEnd()
+}
+
+// addEnumValue inserts values[0], placing it before or after values[1] when given
+func addEnumValue(enum *types.Enum, values []string, before bool) {
+	value := values[0]
+	for _, v := range enum.Values {
+		if v == value {
+			return
+		}
+	}
+
+	if len(values) == 2 {
+		for i, v := range enum.Values {
+			if v != values[1] {
+				continue
+			}
+			pos := i + 1
+			if before {
+				pos = i
+			}
+			enum.Values = append(enum.Values[:pos], append([]string{value}, enum.Values[pos:]...)...)
+			return
+		}
+	}
+	enum.Values = append(enum.Values, value)
+}
+
+// dropTypes handles DROP TYPE [IF EXISTS] a, b [CASCADE | RESTRICT]
+func (p *ddlParser) dropTypes() error {
+	if err := p.expect("drop", "type"); err != nil {
+		return err
+	}
+	return p.dropNames("type name", func(schemaName, name string) {
+		if enum := p.cat.FindEnum(schemaName, name); enum != nil {
+			p.cat.RemoveEnum(enum)
+		}
+	})
+}
+
+// resolveEnums links every column whose type is a known enum to that enum.
+// Inline MySQL ENUM('a','b') columns get an enum named after the table and column.
+func resolveEnums(cat *types.Catalog) {
+	for _, tbl := range cat.Tables {
+		for i := range tbl.Columns {
+			col := &tbl.Columns[i]
+			if strings.HasPrefix(col.Type, "ENUM(") {
+				col.Enum = &types.Enum{
+					Schema: tbl.Schema,
+					Name:   inflection.Singular(tbl.Name) + "_" + strings.ToLower(col.Name),
+					Values: parseStringList(col.Type),
+				}
+				continue
+			}
+			schemaName, typeName, qualified := strings.Cut(strings.ToLower(col.Type), ".")
+			if !qualified {
+				schemaName, typeName = tbl.Schema, schemaName
+			}
+			col.Enum = cat.FindEnum(schemaName, typeName)
+		}
+	}
+}
+
+// parseStringList extracts the single-quoted string literals of a column type
+// such as ENUM('a','b'), which dataType writes with standard quoting
+func parseStringList(s string) []string {
+	var out []string
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\'' {
+			continue
+		}
+		var sb strings.Builder
+		j := i + 1
+		for ; j < len(s); j++ {
+			if s[j] == '\'' {
+				if j+1 < len(s) && s[j+1] == '\'' {
+					sb.WriteByte('\'')
+					j++
+					continue
+				}
+				break
+			}
+			sb.WriteByte(s[j])
+		}
+		out = append(out, sb.String())
+		i = j
+	}
+	return out
+}
@@ -0,0 +1,208 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// createIndex parses CREATE [UNIQUE] INDEX [CONCURRENTLY] [IF NOT EXISTS] [name]
+// ON [ONLY] table [USING method] (columns) [WHERE ...].
+// Indexes on expressions are ignored, and partial unique indexes are recorded as
+// plain indexes because they do not make the columns unique.
+func (p *ddlParser) createIndex() error {
+	if err := p.expect("create"); err != nil {
+		return err
+	}
+	unique := p.accept("unique")
+	if err := p.expect("index"); err != nil {
+		return err
+	}
+	p.accept("concurrently")
+	p.accept("if", "not", "exists")
+
+	name := ""
+	if !p.at("on") {
+		var err error
+		if _, name, err = p.qualifiedName("index name"); err != nil {
+			return err
+		}
+	}
+	if p.accept("using") {
+		// MySQL places the method before ON
+		p.i++
+	}
+	if err := p.expect("on"); err != nil {
+		return err
+	}
+	p.accept("only")
+	tableTok := p.peek()
+	schemaName, tableName, err := p.qualifiedName("table name")
+	if err != nil {
+		return err
+	}
+	if p.accept("using") {
+		p.i++
+	}
+	names, err := p.indexColumns()
+	if err != nil {
+		return err
+	}
+	for depth := 0; !p.atEOF(); p.i++ {
+		switch tok := p.peek(); {
+		case tok.isPunct("("):
+			depth++
+		case tok.isPunct(")"):
+			depth--
+		case depth == 0 && tok.is("where"):
+			unique = false
+		}
+	}
+
+	tbl := p.cat.FindTable(schemaName, tableName)
+	if tbl == nil {
+		p.warnf(tableTok, "ignoring index on %s: table is not created by an earlier migration", tableName)
+		return nil
+	}
+	if names != nil {
+		addIndex(tbl, types.Index{Name: name, Columns: names, Unique: unique})
+	}
+	return nil
+}
+
+// dropIndexes handles DROP INDEX [CONCURRENTLY] [IF EXISTS] name [, ...]
+// [CASCADE | RESTRICT] and the MySQL form DROP INDEX name ON table
+func (p *ddlParser) dropIndexes() error {
+	if err := p.expect("drop", "index"); err != nil {
+		return err
+	}
+	p.accept("concurrently")
+	p.accept("if", "exists")
+	var names []string
+	for {
+		_, name, err := p.qualifiedName("index name")
+		if err != nil {
+			return err
+		}
+		names = append(names, name)
+		if !p.acceptPunct(",") {
+			break
+		}
+	}
+
+	tables := p.cat.Tables
+	if p.accept("on") {
+		schemaName, tableName, err := p.qualifiedName("table name")
+		if err != nil {
+			return err
+		}
+		tables = nil
+		if tbl := p.cat.FindTable(schemaName, tableName); tbl != nil {
+			tables = []*types.Table{tbl}
+		}
+		// ALGORITHM and LOCK options do not change the table
+		p.i = len(p.toks)
+	}
+	p.acceptAny("cascade", "restrict")
+	if err := p.expectEnd(); err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		for _, tbl := range tables {
+			dropIndex(tbl, name)
+		}
+	}
+	return nil
+}
+
+// alterIndex handles ALTER INDEX [IF EXISTS] name RENAME TO new_name. Other
+// actions, such as SET TABLESPACE, do not change the index.
+func (p *ddlParser) alterIndex() error {
+	if err := p.expect("alter", "index"); err != nil {
+		return err
+	}
+	p.accept("if", "exists")
+	_, oldName, err := p.qualifiedName("index name")
+	if err != nil {
+		return err
+	}
+	if !p.accept("rename", "to") {
+		return nil
+	}
+	newName, err := p.ident("new name")
+	if err != nil {
+		return err
+	}
+	for _, tbl := range p.cat.Tables {
+		renameIndex(tbl, oldName, newName)
+	}
+	return p.expectEnd()
+}
+
+// addIndex records an index, replacing an earlier one with the same name
+func addIndex(tbl *types.Table, idx types.Index) {
+	if idx.Name != "" {
+		removeIndexes(tbl, idx.Name)
+	}
+	tbl.Indexes = append(tbl.Indexes, idx)
+}
+
+// renameIndex renames an index or unique constraint of the table
+func renameIndex(tbl *types.Table, oldName, newName string) {
+	for i := range tbl.Indexes {
+		if strings.EqualFold(tbl.Indexes[i].Name, oldName) {
+			tbl.Indexes[i].Name = newName
+		}
+	}
+}
+
+// dropIndex removes a named index or unique constraint from the table. The
+// column it made unique is no longer unique, unless the primary key or another
+// unique index still covers it.
+func dropIndex(tbl *types.Table, name string) {
+	for _, idx := range removeIndexes(tbl, name) {
+		if !idx.Unique || len(idx.Columns) != 1 {
+			continue
+		}
+		if col := tbl.FindColumn(idx.Columns[0]); col != nil && !uniqueColumn(tbl, col) {
+			col.Unique = false
+		}
+	}
+}
+
+// removeIndexes removes the indexes with a name from the table and returns them
+func removeIndexes(tbl *types.Table, name string) []types.Index {
+	var removed []types.Index
+	kept := tbl.Indexes[:0]
+	for _, idx := range tbl.Indexes {
+		if strings.EqualFold(idx.Name, name) {
+			removed = append(removed, idx)
+		} else {
+			kept = append(kept, idx)
+		}
+	}
+	tbl.Indexes = kept
+	return removed
+}
+
+// uniqueColumn reports whether a column alone is the primary key or a unique index
+func uniqueColumn(tbl *types.Table, col *types.Column) bool {
+	if col.PrimaryKey {
+		keys := 0
+		for _, other := range tbl.Columns {
+			if other.PrimaryKey {
+				keys++
+			}
+		}
+		if keys == 1 {
+			return true
+		}
+	}
+	for _, idx := range tbl.Indexes {
+		if idx.Unique && len(idx.Columns) == 1 && strings.EqualFold(idx.Columns[0], col.Name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+func TestDropUniqueConstraint(t *testing.T) {
+	tests := []struct {
+		name       string
+		dialect    types.Dialect
+		migrations []string
+		unique     bool // whether code is still unique after the migrations
+		indexes    int
+	}{
+		{
+			name:    "named table constraint",
+			dialect: types.DialectPostgres,
+			migrations: []string{
+				"CREATE TABLE items (id UUID PRIMARY KEY, code TEXT NOT NULL);",
+				"ALTER TABLE items ADD CONSTRAINT items_code_uq UNIQUE (code);",
+				"ALTER TABLE items DROP CONSTRAINT items_code_uq;",
+			},
+		},
+		{
+			name:    "implicit name of a column constraint",
+			dialect: types.DialectPostgres,
+			migrations: []string{
+				"CREATE TABLE items (id UUID PRIMARY KEY, code TEXT NOT NULL UNIQUE);",
+				"ALTER TABLE items DROP CONSTRAINT IF EXISTS items_code_key;",
+			},
+		},
+		{
+			name:    "named column constraint",
+			dialect: types.DialectPostgres,
+			migrations: []string{
+				"CREATE TABLE items (id UUID PRIMARY KEY, code TEXT CONSTRAINT uq_code UNIQUE);",
+				"ALTER TABLE items DROP CONSTRAINT uq_code;",
+			},
+		},
+		{
+			name:    "unique index",
+			dialect: types.DialectPostgres,
+			migrations: []string{
+				"CREATE TABLE items (id UUID PRIMARY KEY, code TEXT NOT NULL);",
+				"CREATE UNIQUE INDEX idx_items_code ON items (code);",
+				"DROP INDEX idx_items_code;",
+			},
+		},
+		{
+			name:    "implicit name of a mysql column constraint",
+			dialect: types.DialectMySQL,
+			migrations: []string{
+				"CREATE TABLE items (id BIGINT PRIMARY KEY, code VARCHAR(64) NOT NULL UNIQUE);",
+				"ALTER TABLE items DROP INDEX code;",
+			},
+		},
+		{
+			name:    "another unique constraint remains",
+			dialect: types.DialectPostgres,
+			migrations: []string{
+				"CREATE TABLE items (id UUID PRIMARY KEY, code TEXT NOT NULL UNIQUE);",
+				"ALTER TABLE items ADD CONSTRAINT items_code_uq UNIQUE (code);",
+				"ALTER TABLE items DROP CONSTRAINT items_code_uq;",
+			},
+			unique:  true,
+			indexes: 1,
+		},
+		{
+			name:    "unique constraint added again",
+			dialect: types.DialectPostgres,
+			migrations: []string{
+				"CREATE TABLE items (id UUID PRIMARY KEY, code TEXT NOT NULL UNIQUE);",
+				"ALTER TABLE items DROP CONSTRAINT items_code_key;",
+				"ALTER TABLE items ADD UNIQUE (code);",
+			},
+			unique:  true,
+			indexes: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for i, sql := range tt.migrations {
+				name := filepath.Join(dir, string(rune('1'+i))+"_items.up.sql")
+				if err := os.WriteFile(name, []byte(sql), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cat, err := LoadCatalog(dir, tt.dialect, NewMigrationReader(types.FormatGolangMigrate))
+			if err != nil {
+				t.Fatalf("LoadCatalog() error = %v", err)
+			}
+			tbl := cat.FindTable("", "items")
+			if tbl == nil {
+				t.Fatal("items not found")
+			}
+			if col := tbl.FindColumn("code"); col.Unique != tt.unique {
+				t.Errorf("code unique = %v, want %v", col.Unique, tt.unique)
+			}
+			if len(tbl.Indexes) != tt.indexes {
+				t.Errorf("indexes = %+v, want %d", tbl.Indexes, tt.indexes)
+			}
+		})
+	}
+}
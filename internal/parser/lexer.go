@@ -0,0 +1,355 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// tokenKind classifies a lexical token of a SQL source
+type tokenKind int
+
+const (
+	tokEOF      tokenKind = iota
+	tokWord               // keyword or bare identifier
+	tokQuoted             // "quoted" (a string in MySQL), `backtick` or [bracketed] identifier
+	tokString             // 'string', E'string', N'string' or $tag$string$tag$ literal
+	tokNumber             // numeric literal
+	tokPunct              // ( ) , ; . [ ]
+	tokOperator           // any other symbol, e.g. = :: || *
+)
+
+// position is a 1-based line and column in a SQL source. Columns count
+// characters (runes), as types.Diagnostic does.
+type position struct {
+	Line int
+	Col  int
+}
+
+// token is a lexical token with its source span
+type token struct {
+	kind  tokenKind
+	text  string // source text
+	value string // identifier without quotes or the decoded string literal
+	pos   position
+	start int // byte offset of the token in the source
+	end   int // byte offset just past the token
+}
+
+// is reports whether the token is the given keyword (case-insensitive)
+func (t token) is(keyword string) bool {
+	return t.kind == tokWord && strings.EqualFold(t.text, keyword)
+}
+
+// isPunct reports whether the token is the given punctuation
+func (t token) isPunct(p string) bool {
+	return t.kind == tokPunct && t.text == p
+}
+
+// describe names the token for error messages
+func (t token) describe() string {
+	if t.kind == tokEOF {
+		return "end of statement"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// sqlComment is a comment with its source span
+type sqlComment struct {
+	text  string // comment text without the -- # or /* */ markers
+	start int
+	end   int
+}
+
+// lexer splits SQL source into tokens, keeping comments aside
+type lexer struct {
+	src      string
+	dialect  types.Dialect
+	i        int
+	line     int
+	col      int
+	tokens   []token
+	comments []sqlComment
+}
+
+// lex tokenizes a SQL source. Unterminated strings, quoted identifiers and
+// block comments are reported at the position they start.
+func lex(src string, dialect types.Dialect) ([]token, []sqlComment, error) {
+	l := &lexer{src: src, dialect: dialect, line: 1, col: 1}
+	for {
+		l.skipSpace()
+		if l.i >= len(l.src) {
+			break
+		}
+		if err := l.scan(); err != nil {
+			return nil, nil, err
+		}
+	}
+	return l.tokens, l.comments, nil
+}
+
+// pos returns the current position
+func (l *lexer) pos() position {
+	return position{Line: l.line, Col: l.col}
+}
+
+// advance moves past n bytes, keeping track of lines and of columns in runes
+func (l *lexer) advance(n int) {
+	for ; n > 0 && l.i < len(l.src); n-- {
+		c := l.src[l.i]
+		l.i++
+		if c == '\n' {
+			l.line++
+			l.col = 1
+		} else if c&0xC0 != 0x80 {
+			// UTF-8 continuation bytes belong to the rune already counted
+			l.col++
+		}
+	}
+}
+
+func (l *lexer) peekByte(offset int) byte {
+	if l.i+offset < len(l.src) {
+		return l.src[l.i+offset]
+	}
+	return 0
+}
+
+func (l *lexer) skipSpace() {
+	for l.i < len(l.src) {
+		switch l.src[l.i] {
+		case ' ', '\t', '\n', '\r', '\f', '\v':
+			l.advance(1)
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) errorf(at position, format string, args ...interface{}) error {
+	return &types.Diagnostic{Line: at.Line, Col: at.Col, Message: fmt.Sprintf(format, args...)}
+}
+
+// emit records a token spanning from start to the current offset
+func (l *lexer) emit(kind tokenKind, start int, at position, value string) {
+	l.tokens = append(l.tokens, token{
+		kind:  kind,
+		text:  l.src[start:l.i],
+		value: value,
+		pos:   at,
+		start: start,
+		end:   l.i,
+	})
+}
+
+// scan reads the comment or token at the current offset
+func (l *lexer) scan() error {
+	start, at := l.i, l.pos()
+	c := l.src[l.i]
+
+	switch {
+	case c == '-' && l.peekByte(1) == '-', c == '#' && l.dialect == types.DialectMySQL:
+		end := strings.IndexByte(l.src[l.i:], '\n')
+		if end == -1 {
+			end = len(l.src) - l.i
+		}
+		text := strings.TrimLeft(l.src[l.i:l.i+end], "-#")
+		l.advance(end)
+		l.comments = append(l.comments, sqlComment{text: strings.TrimSpace(text), start: start, end: l.i})
+		return nil
+
+	case c == '/' && l.peekByte(1) == '*':
+		return l.scanBlockComment(start, at)
+
+	case c == '\'', c == '"' && l.dialect == types.DialectMySQL:
+		// MySQL also quotes strings with double quotes
+		return l.scanString(start, at, l.dialect == types.DialectMySQL)
+
+	case (c == 'E' || c == 'e') && l.peekByte(1) == '\'' && l.dialect != types.DialectMySQL:
+		// E'...' takes backslash escapes
+		l.advance(1)
+		return l.scanString(start, at, true)
+
+	case (c == 'N' || c == 'n' || c == 'X' || c == 'x' || c == 'B' || c == 'b') && l.peekByte(1) == '\'':
+		// national, hex and bit string literals
+		l.advance(1)
+		return l.scanString(start, at, l.dialect == types.DialectMySQL)
+
+	case c == '"', c == '`', c == '[' && l.dialect == types.DialectSQLite:
+		return l.scanQuotedIdent(start, at)
+
+	case c == '$' && dollarTag(l.src[l.i:]) != "":
+		tag := dollarTag(l.src[l.i:])
+		end := strings.Index(l.src[l.i+len(tag):], tag)
+		if end == -1 {
+			return l.errorf(at, "unterminated dollar-quoted string %s", tag)
+		}
+		value := l.src[l.i+len(tag) : l.i+len(tag)+end]
+		l.advance(len(tag) + end + len(tag))
+		l.emit(tokString, start, at, value)
+		return nil
+
+	case isDigit(c), c == '.' && isDigit(l.peekByte(1)):
+		l.scanNumber()
+		l.emit(tokNumber, start, at, l.src[start:l.i])
+		return nil
+
+	case isWordStart(c):
+		for l.i < len(l.src) && isWordChar(l.src[l.i]) {
+			l.advance(1)
+		}
+		l.emit(tokWord, start, at, l.src[start:l.i])
+		return nil
+
+	case strings.IndexByte("(),;.[]", c) != -1:
+		l.advance(1)
+		l.emit(tokPunct, start, at, l.src[start:l.i])
+		return nil
+	}
+
+	// operators: :: and the common two character comparisons stay together
+	n := 1
+	switch l.src[l.i:min(l.i+2, len(l.src))] {
+	case "::", "<=", ">=", "<>", "!=", "||", "->", "=>":
+		n = 2
+	}
+	l.advance(n)
+	l.emit(tokOperator, start, at, l.src[start:l.i])
+	return nil
+}
+
+// scanBlockComment reads a /* ... */ comment. PostgreSQL block comments nest.
+func (l *lexer) scanBlockComment(start int, at position) error {
+	l.advance(2)
+	depth := 1
+	for l.i < len(l.src) {
+		switch {
+		case l.src[l.i] == '*' && l.peekByte(1) == '/':
+			depth--
+			l.advance(2)
+			if depth == 0 {
+				text := l.src[start+2 : l.i-2]
+				l.comments = append(l.comments, sqlComment{text: strings.TrimSpace(text), start: start, end: l.i})
+				return nil
+			}
+		case l.src[l.i] == '/' && l.peekByte(1) == '*' && l.dialect == types.DialectPostgres:
+			depth++
+			l.advance(2)
+		default:
+			l.advance(1)
+		}
+	}
+	return l.errorf(at, "unterminated block comment")
+}
+
+// scanString reads a quoted string literal starting at the opening quote.
+// A doubled quote is an escaped quote; backslash escapes are decoded when enabled.
+func (l *lexer) scanString(start int, at position, backslash bool) error {
+	quote := l.src[l.i]
+	l.advance(1)
+	var sb strings.Builder
+	for l.i < len(l.src) {
+		c := l.src[l.i]
+		switch {
+		case c == '\\' && backslash && l.i+1 < len(l.src):
+			switch next := l.src[l.i+1]; next {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case 'r':
+				sb.WriteByte('\r')
+			case '0':
+				sb.WriteByte(0)
+			default:
+				sb.WriteByte(next)
+			}
+			l.advance(2)
+		case c == quote:
+			if l.peekByte(1) == quote {
+				sb.WriteByte(quote)
+				l.advance(2)
+				continue
+			}
+			l.advance(1)
+			l.emit(tokString, start, at, sb.String())
+			return nil
+		default:
+			sb.WriteByte(c)
+			l.advance(1)
+		}
+	}
+	return l.errorf(at, "unterminated string literal")
+}
+
+// scanQuotedIdent reads a "quoted", `backtick` or [bracketed] identifier.
+// Doubled quote characters are escaped quotes.
+func (l *lexer) scanQuotedIdent(start int, at position) error {
+	quote := l.src[l.i]
+	if quote == '[' {
+		quote = ']'
+	}
+	l.advance(1)
+	var sb strings.Builder
+	for l.i < len(l.src) {
+		c := l.src[l.i]
+		if c == quote {
+			if quote != ']' && l.peekByte(1) == quote {
+				sb.WriteByte(c)
+				l.advance(2)
+				continue
+			}
+			l.advance(1)
+			l.emit(tokQuoted, start, at, sb.String())
+			return nil
+		}
+		sb.WriteByte(c)
+		l.advance(1)
+	}
+	return l.errorf(at, "unterminated quoted identifier")
+}
+
+// scanNumber reads digits with an optional fraction and exponent
+func (l *lexer) scanNumber() {
+	for l.i < len(l.src) && (isDigit(l.src[l.i]) || l.src[l.i] == '.') {
+		l.advance(1)
+	}
+	if c := l.peekByte(0); c == 'e' || c == 'E' {
+		next := l.peekByte(1)
+		if isDigit(next) || (next == '+' || next == '-') && isDigit(l.peekByte(2)) {
+			l.advance(2)
+			for l.i < len(l.src) && isDigit(l.src[l.i]) {
+				l.advance(1)
+			}
+		}
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isWordStart reports whether c starts a keyword or bare identifier.
+// Bytes of multi-byte UTF-8 characters are accepted as letters.
+func isWordStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+func isWordChar(c byte) bool {
+	return isWordStart(c) || isDigit(c) || c == '$'
+}
+
+// dollarTag returns the opening $tag$ at the start of s, or "" if there is none
+func dollarTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		if c == '$' {
+			return s[:i+1]
+		}
+		if !(c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || i > 1 && c >= '0' && c <= '9') {
+			return ""
+		}
+	}
+	return ""
+}
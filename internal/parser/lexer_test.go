@@ -0,0 +1,166 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// describeTokens renders tokens as "kind:value" for comparisons
+func describeTokens(toks []token) []string {
+	names := map[tokenKind]string{
+		tokWord:     "word",
+		tokQuoted:   "quoted",
+		tokString:   "string",
+		tokNumber:   "number",
+		tokPunct:    "punct",
+		tokOperator: "op",
+	}
+	var out []string
+	for _, tok := range toks {
+		out = append(out, names[tok.kind]+":"+tok.value)
+	}
+	return out
+}
+
+func TestLex(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect types.Dialect
+		src     string
+		want    []string
+	}{
+		{
+			name:    "words, punctuation and numbers",
+			dialect: types.DialectPostgres,
+			src:     "price NUMERIC(12,2) DEFAULT 1.5e3;",
+			want:    []string{"word:price", "word:NUMERIC", "punct:(", "number:12", "punct:,", "number:2", "punct:)", "word:DEFAULT", "number:1.5e3", "punct:;"},
+		},
+		{
+			name:    "two character operators stay together",
+			dialect: types.DialectPostgres,
+			src:     "'a'::status <> b",
+			want:    []string{"string:a", "op:::", "word:status", "op:<>", "word:b"},
+		},
+		{
+			name:    "doubled quotes and separators inside strings",
+			dialect: types.DialectPostgres,
+			src:     "DEFAULT 'it''s a,b; (c)'",
+			want:    []string{"word:DEFAULT", "string:it's a,b; (c)"},
+		},
+		{
+			name:    "escape strings",
+			dialect: types.DialectPostgres,
+			src:     `E'a\nb\'c'`,
+			want:    []string{"string:a\nb'c"},
+		},
+		{
+			name:    "dollar quoted bodies",
+			dialect: types.DialectPostgres,
+			src:     "AS $body$ SELECT 1; $body$ LANGUAGE sql",
+			want:    []string{"word:AS", "string: SELECT 1; ", "word:LANGUAGE", "word:sql"},
+		},
+		{
+			name:    "quoted identifiers",
+			dialect: types.DialectPostgres,
+			src:     `"Order ""Items""".id`,
+			want:    []string{`quoted:Order "Items"`, "punct:.", "word:id"},
+		},
+		{
+			name:    "comments are kept aside",
+			dialect: types.DialectPostgres,
+			src:     "a -- trailing, comment\n/* block /* nested */ still */ b",
+			want:    []string{"word:a", "word:b"},
+		},
+		{
+			name:    "mysql backticks, double quoted strings and hash comments",
+			dialect: types.DialectMySQL,
+			src:     "`order` COMMENT \"it's\" # note\nx",
+			want:    []string{"quoted:order", "word:COMMENT", "string:it's", "word:x"},
+		},
+		{
+			name:    "sqlite bracketed identifiers",
+			dialect: types.DialectSQLite,
+			src:     "[my column] TEXT",
+			want:    []string{"quoted:my column", "word:TEXT"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toks, _, err := lex(tt.src, tt.dialect)
+			if err != nil {
+				t.Fatalf("lex() error = %v", err)
+			}
+			if got := describeTokens(toks); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("lex() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLexComments(t *testing.T) {
+	_, comments, err := lex("-- first\nCREATE /* second */ TABLE t ()", types.DialectPostgres)
+	if err != nil {
+		t.Fatalf("lex() error = %v", err)
+	}
+	var got []string
+	for _, c := range comments {
+		got = append(got, c.text)
+	}
+	if want := []string{"first", "second"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("comments = %q, want %q", got, want)
+	}
+}
+
+func TestLexPositions(t *testing.T) {
+	src := "CREATE TABLE t (\n  id  INT,\n  név TEXT\n)"
+	toks, _, err := lex(src, types.DialectPostgres)
+	if err != nil {
+		t.Fatalf("lex() error = %v", err)
+	}
+
+	want := map[string]position{
+		"CREATE": {Line: 1, Col: 1},
+		"id":     {Line: 2, Col: 3},
+		"INT":    {Line: 2, Col: 7},
+		"TEXT":   {Line: 3, Col: 7}, // columns count runes, not bytes
+		")":      {Line: 4, Col: 1},
+	}
+	for _, tok := range toks {
+		if pos, ok := want[tok.text]; ok && tok.pos != pos {
+			t.Errorf("position of %q = %d:%d, want %d:%d", tok.text, tok.pos.Line, tok.pos.Col, pos.Line, pos.Col)
+		}
+		if src[tok.start:tok.end] != tok.text {
+			t.Errorf("span of %q = %q", tok.text, src[tok.start:tok.end])
+		}
+	}
+}
+
+func TestLexErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"unterminated string", "DEFAULT\n  'abc", "2:3: unterminated string literal"},
+		{"unterminated quoted identifier", `CREATE TABLE "users (`, "1:14: unterminated quoted identifier"},
+		{"unterminated block comment", "a /* b /* c */", "1:3: unterminated block comment"},
+		{"unterminated dollar quote", "AS $$ SELECT", "1:4: unterminated dollar-quoted string $$"},
+		{"after multibyte characters", "DEFAULT 'héllo' 'x", "1:17: unterminated string literal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := lex(tt.src, types.DialectPostgres)
+			if err == nil {
+				t.Fatal("lex() error = nil")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("lex() error = %q, want %q", err, tt.want)
+			}
+		})
+	}
+}
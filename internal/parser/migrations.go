@@ -0,0 +1,632 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// ListMigrations returns the migration files under root in version order
+func ListMigrations(root string, reader MigrationReader) ([]string, error) {
+	var files []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && reader.IsMigration(path) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		return compareVersions(filepath.Base(files[i]), filepath.Base(files[j])) < 0
+	})
+	return files, nil
+}
+
+// LoadCatalog replays every migration under root in version order
+func LoadCatalog(root string, dialect types.Dialect, reader MigrationReader) (*types.Catalog, error) {
+	files, err := ListMigrations(root, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	cat := &types.Catalog{}
+	for _, file := range files {
+		if err := applyFile(cat, file, dialect, reader); err != nil {
+			return nil, err
+		}
+	}
+	resolveEnums(cat)
+	return cat, nil
+}
+
+// LoadSchemaFile reads a schema snapshot, such as the output of pg_dump --schema-only
+// or the schema.sql kept by a migration tool, into a catalog
+func LoadSchemaFile(path string, dialect types.Dialect) (*types.Catalog, error) {
+	cat := &types.Catalog{}
+	if err := applyFile(cat, path, dialect, snapshotReader{}); err != nil {
+		return nil, err
+	}
+	resolveEnums(cat)
+	return cat, nil
+}
+
+// ParseFile reads the tables, views and enums of a single migration file on its
+// own, without replaying the migrations before it. Statements altering tables
+// of other files are reported as warnings.
+func ParseFile(path string, dialect types.Dialect, reader MigrationReader) (*types.Catalog, error) {
+	cat := &types.Catalog{}
+	if err := applyFile(cat, path, dialect, reader); err != nil {
+		return nil, err
+	}
+	resolveEnums(cat)
+	return cat, nil
+}
+
+// FindMigration returns the migration under root that creates schema.table,
+// matched by the parsed table or view name rather than the file name. The
+// migrations are replayed, so a table renamed later is found by its new name.
+func FindMigration(root, schema, table string, dialect types.Dialect, reader MigrationReader) (string, error) {
+	cat, err := LoadCatalog(root, dialect, reader)
+	if err != nil {
+		return "", err
+	}
+	tbl := cat.FindTable(schema, table)
+	if tbl == nil {
+		return "", fmt.Errorf("no migration for %s.%s", schema, table)
+	}
+	return tbl.Source, nil
+}
+
+// applyFile applies every statement of the up section of a migration file to the catalog
+func applyFile(cat *types.Catalog, path string, dialect types.Dialect, reader MigrationReader) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	sql, err := reader.Up(string(data))
+	if err != nil {
+		return diagnostic(path, position{}, err)
+	}
+	stmts, err := splitStatements(sql, dialect)
+	if err != nil {
+		return diagnostic(path, position{}, err)
+	}
+	for _, st := range stmts {
+		if err := applyStatement(cat, path, st, dialect); err != nil {
+			return diagnostic(path, st.Pos, err)
+		}
+	}
+	return nil
+}
+
+// diagnostic positions an error in file. Errors that are already positioned keep
+// their position; others are reported at the start of the statement.
+func diagnostic(file string, at position, err error) error {
+	var d *types.Diagnostic
+	if errors.As(err, &d) {
+		positioned := *d
+		positioned.File = file
+		return &positioned
+	}
+	return &types.Diagnostic{File: file, Line: at.Line, Col: at.Col, Message: err.Error()}
+}
+
+// compareVersions orders migration file names by their leading numeric version
+func compareVersions(a, b string) int {
+	va, vb := leadingDigits(a), leadingDigits(b)
+	if va != "" && vb != "" {
+		ta, tb := strings.TrimLeft(va, "0"), strings.TrimLeft(vb, "0")
+		if len(ta) != len(tb) {
+			if len(ta) < len(tb) {
+				return -1
+			}
+			return 1
+		}
+		if c := strings.Compare(ta, tb); c != 0 {
+			return c
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+func leadingDigits(s string) string {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return s[:i]
+}
+
+// applyStatement applies a single DDL statement to the catalog.
+// Statements that do not change table, view or enum shape are ignored.
+func applyStatement(cat *types.Catalog, source string, st statement, dialect types.Dialect) error {
+	p := newDDLParser(cat, source, st, dialect)
+	switch {
+	case p.atCreate(tableModifiers, "table"):
+		tbl, err := p.createTable()
+		if err != nil || tbl == nil {
+			return err
+		}
+		tbl.Source = source
+		cat.AddTable(tbl)
+	case p.at("alter", "table"):
+		return p.alterTable()
+	case p.at("rename", "table"):
+		return p.renameTables()
+	case p.at("drop", "table"):
+		return p.dropTables()
+	case p.atCreateEnum():
+		enum, err := p.createEnum()
+		if err != nil {
+			return err
+		}
+		cat.AddEnum(enum)
+	case p.at("alter", "type"):
+		return p.alterType()
+	case p.at("drop", "type"):
+		return p.dropTypes()
+	case p.atCreate(viewModifiers, "view"):
+		view, err := p.createView()
+		if err != nil {
+			return err
+		}
+		view.Source = source
+		cat.AddTable(view)
+	case p.at("alter", "view"), p.at("alter", "materialized", "view"):
+		return p.alterView()
+	case p.at("drop", "view"), p.at("drop", "materialized", "view"):
+		return p.dropViews()
+	case p.at("create", "index"), p.at("create", "unique", "index"):
+		return p.createIndex()
+	case p.at("drop", "index"):
+		return p.dropIndexes()
+	case p.at("alter", "index"):
+		return p.alterIndex()
+	case p.at("comment", "on"):
+		return p.commentOn()
+	case p.at("create", "sequence"), p.at("alter", "sequence"):
+		return p.sequence()
+	default:
+		if kind := p.statementKind(); kind != "" && !quietStatements[strings.ToLower(kind)] {
+			p.warnf(p.peek(), "ignoring %s statement: only tables, views, indexes, enums, sequences and comments are read", kind)
+		}
+	}
+	return nil
+}
+
+// quietStatements are the kinds of statements that control the session or a
+// transaction, work on rows or create an empty schema. Skipping them loses
+// nothing, so it is not reported.
+var quietStatements = map[string]bool{
+	"begin": true, "start": true, "commit": true, "end": true, "rollback": true,
+	"savepoint": true, "release": true, "set": true, "reset": true, "pragma": true,
+	"use": true, "select": true, "insert": true, "update": true, "delete": true,
+	"copy": true, "lock": true, "analyze": true, "vacuum": true,
+	"create schema": true,
+}
+
+// statementKind names a statement by its leading keywords, e.g. "CREATE FUNCTION"
+// for CREATE OR REPLACE FUNCTION, or "GRANT"
+func (p *ddlParser) statementKind() string {
+	first := p.peek()
+	if first.kind != tokWord {
+		return ""
+	}
+	kind := strings.ToUpper(first.text)
+	if p.atAny("create", "alter", "drop") {
+		n := 1
+		if p.peekAt(1).is("or") && p.peekAt(2).is("replace") {
+			n = 3
+		}
+		if next := p.peekAt(n); next.kind == tokWord {
+			kind += " " + strings.ToUpper(next.text)
+		}
+	}
+	return kind
+}
+
+// alterTable parses ALTER TABLE [IF EXISTS] [ONLY] name action [, ...]
+func (p *ddlParser) alterTable() error {
+	if err := p.expect("alter", "table"); err != nil {
+		return err
+	}
+	ifExists := p.accept("if", "exists")
+	p.accept("only")
+	nameTok := p.peek()
+	schemaName, tableName, err := p.qualifiedName("table name")
+	if err != nil {
+		return err
+	}
+	p.acceptOperator("*")
+
+	tbl := p.cat.FindTable(schemaName, tableName)
+	if tbl == nil {
+		// ALTER TABLE IF EXISTS on a missing table is a no-op
+		if !ifExists {
+			p.warnf(nameTok, "ignoring ALTER TABLE %s: table is not created by an earlier migration", tableName)
+		}
+		return nil
+	}
+
+	for {
+		if err := p.alterAction(tbl); err != nil {
+			return err
+		}
+		if !p.acceptPunct(",") {
+			return p.expectEnd()
+		}
+	}
+}
+
+// alterAction applies one ALTER TABLE action such as ADD COLUMN or RENAME.
+// Actions that do not change the table shape, such as OWNER TO or ENABLE TRIGGER,
+// are skipped; unknown actions are reported.
+func (p *ddlParser) alterAction(tbl *types.Table) error {
+	tok := p.peek()
+	switch {
+	case p.accept("add"):
+		return p.alterAdd(tbl)
+	case p.accept("drop"):
+		return p.alterDrop(tbl)
+	case p.accept("alter", "constraint"):
+		p.skipAction()
+	case p.accept("alter"):
+		p.accept("column")
+		return p.alterColumn(tbl)
+	case p.dialect == types.DialectMySQL && p.atAny("modify", "change"):
+		return p.alterRedefineColumn(tbl)
+	case p.accept("rename"):
+		return p.alterRename(tbl)
+	case p.accept("set", "schema"):
+		name, err := p.ident("schema name")
+		if err != nil {
+			return err
+		}
+		tbl.Schema = name
+	case p.accept("comment"):
+		p.acceptOperator("=")
+		text, err := p.stringLiteral()
+		if err != nil {
+			return err
+		}
+		tbl.Comment = text
+	case p.accept("inherit"):
+		p.warnf(tok, "ignoring INHERIT: inherited columns are not added to %s", tbl.Name)
+		p.skipAction()
+	case p.atAny("owner", "enable", "disable", "force", "no", "cluster", "set", "reset", "replica",
+		"validate", "attach", "detach", "of", "not", "convert", "algorithm", "lock", "order", "discard", "import"):
+		p.skipAction()
+	case p.dialect == types.DialectMySQL && tok.kind == tokWord:
+		return p.mysqlTableOption()
+	default:
+		p.warnf(tok, "ignoring ALTER TABLE %s action %s", tbl.Name, tok.describe())
+		p.skipAction()
+	}
+	return nil
+}
+
+// alterAdd handles ADD [COLUMN] [IF NOT EXISTS] <definition> and ADD <table constraint>.
+// MySQL may add several parenthesized columns at once.
+func (p *ddlParser) alterAdd(tbl *types.Table) error {
+	switch {
+	case p.atTableConstraint():
+		c, err := p.tableConstraint()
+		if err != nil {
+			return err
+		}
+		if c != nil {
+			p.applyConstraint(tbl, c)
+		}
+		return nil
+	case p.accept("partition"):
+		p.skipAction()
+		return nil
+	}
+
+	p.accept("column")
+	ifNotExists := p.accept("if", "not", "exists")
+	if !p.acceptPunct("(") {
+		return p.addColumn(tbl, ifNotExists)
+	}
+	for {
+		if err := p.addColumn(tbl, ifNotExists); err != nil {
+			return err
+		}
+		if !p.acceptPunct(",") {
+			return p.expectPunct(")")
+		}
+	}
+}
+
+// addColumn parses a column definition and adds it to the table
+func (p *ddlParser) addColumn(tbl *types.Table, ifNotExists bool) error {
+	nameTok := p.peek()
+	col, inline, err := p.columnDef()
+	if err != nil {
+		return err
+	}
+	if tbl.FindColumn(col.Name) != nil {
+		if ifNotExists {
+			return nil
+		}
+		return p.errorf(nameTok, "column %q of %s already exists", col.Name, tbl.Name)
+	}
+	at, err := p.columnPosition(tbl)
+	if err != nil {
+		return err
+	}
+	insertColumn(tbl, col, at)
+	for _, c := range inline {
+		p.applyConstraint(tbl, c)
+	}
+	return nil
+}
+
+// alterDrop handles DROP [COLUMN] [IF EXISTS] <name> [CASCADE | RESTRICT]
+// and DROP CONSTRAINT [IF EXISTS] <name>, plus the MySQL forms
+// DROP FOREIGN KEY, DROP PRIMARY KEY and DROP INDEX/KEY
+func (p *ddlParser) alterDrop(tbl *types.Table) error {
+	switch {
+	case p.accept("constraint"), p.accept("foreign", "key"):
+		p.accept("if", "exists")
+		name, err := p.ident("constraint name")
+		if err != nil {
+			return err
+		}
+		dropConstraint(tbl, name)
+	case p.accept("primary", "key"):
+		for i := range tbl.Columns {
+			tbl.Columns[i].PrimaryKey = false
+		}
+	case p.acceptAny("index", "key"):
+		p.accept("if", "exists")
+		name, err := p.ident("index name")
+		if err != nil {
+			return err
+		}
+		dropIndex(tbl, name)
+	case p.acceptAny("check", "partition"):
+		p.skipAction()
+		return nil
+	default:
+		p.accept("column")
+		ifExists := p.accept("if", "exists")
+		nameTok := p.peek()
+		name, err := p.ident("column name")
+		if err != nil {
+			return err
+		}
+		if !dropColumn(tbl, name) && !ifExists {
+			return p.errorf(nameTok, "column %q of %s does not exist", name, tbl.Name)
+		}
+	}
+	p.acceptAny("cascade", "restrict")
+	return nil
+}
+
+// alterColumn handles ALTER [COLUMN] <name> TYPE / SET NOT NULL / DROP NOT NULL,
+// SET DEFAULT / DROP DEFAULT and identity or generated expression changes
+func (p *ddlParser) alterColumn(tbl *types.Table) error {
+	nameTok := p.peek()
+	name, err := p.ident("column name")
+	if err != nil {
+		return err
+	}
+	col := tbl.FindColumn(name)
+	if col == nil {
+		return p.errorf(nameTok, "column %q of %s does not exist", name, tbl.Name)
+	}
+
+	tok := p.peek()
+	switch {
+	case p.accept("type"), p.accept("set", "data", "type"):
+		if col.Type, err = p.dataType(col); err != nil {
+			return err
+		}
+		setTypeModifiers(col)
+		if p.accept("collate") {
+			if _, _, err := p.qualifiedName("collation"); err != nil {
+				return err
+			}
+		}
+		if p.accept("using") {
+			p.skipAction()
+		}
+	case p.accept("set", "not", "null"):
+		col.Nullable = false
+	case p.accept("drop", "not", "null"):
+		col.Nullable = true
+	case p.accept("set", "default"):
+		expr, err := p.expression("SET DEFAULT")
+		if err != nil {
+			return err
+		}
+		col.Default = normalizeDefault(expr)
+		setSerialDefault(col)
+	case p.accept("drop", "default"):
+		col.Default = ""
+	case p.accept("add", "generated"):
+		col.Identity = true
+		col.Nullable = false
+		p.skipAction()
+	case p.accept("drop", "identity"):
+		col.Identity = false
+		p.accept("if", "exists")
+	case p.accept("drop", "expression"):
+		col.Generated = false
+		p.accept("if", "exists")
+	case p.atAny("set", "reset", "restart"):
+		// statistics, storage, options and sequence settings
+		p.skipAction()
+	default:
+		p.warnf(tok, "ignoring ALTER COLUMN %s clause %s", col.Name, tok.describe())
+		p.skipAction()
+	}
+	return nil
+}
+
+// alterRename handles RENAME [COLUMN] a TO b, RENAME CONSTRAINT|INDEX|KEY a TO b
+// and RENAME TO|AS new_name
+func (p *ddlParser) alterRename(tbl *types.Table) error {
+	if p.acceptAny("to", "as") {
+		_, newName, err := p.qualifiedName("table name")
+		if err != nil {
+			return err
+		}
+		renameTableTo(p.cat, tbl, newName)
+		return nil
+	}
+
+	index := p.acceptAny("constraint", "index", "key")
+	if !index {
+		p.accept("column")
+	}
+	oldTok := p.peek()
+	oldName, err := p.ident("name")
+	if err != nil {
+		return err
+	}
+	if err := p.expect("to"); err != nil {
+		return err
+	}
+	newName, err := p.ident("new name")
+	if err != nil {
+		return err
+	}
+
+	if index {
+		renameIndex(tbl, oldName, newName)
+		return nil
+	}
+	col := tbl.FindColumn(oldName)
+	if col == nil {
+		return p.errorf(oldTok, "column %q of %s does not exist", oldName, tbl.Name)
+	}
+	oldName = col.Name
+	col.Name = newName
+	renameColumnReferences(p.cat, tbl, oldName, newName)
+	return nil
+}
+
+// renameTableTo renames a table and points foreign keys at its new name
+func renameTableTo(cat *types.Catalog, tbl *types.Table, newName string) {
+	oldName := tbl.Name
+	renameTable(tbl, newName)
+	renameTableReferences(cat, tbl, oldName)
+}
+
+// insertColumn inserts a column at index at, or appends it when at is past the end
+func insertColumn(tbl *types.Table, col types.Column, at int) {
+	if at >= len(tbl.Columns) {
+		tbl.Columns = append(tbl.Columns, col)
+		return
+	}
+	tbl.Columns = append(tbl.Columns[:at+1], tbl.Columns[at:]...)
+	tbl.Columns[at] = col
+}
+
+// dropColumn removes a column and the constraints depending on it, reporting
+// whether the column existed
+func dropColumn(tbl *types.Table, name string) bool {
+	for i := range tbl.Columns {
+		if strings.EqualFold(tbl.Columns[i].Name, name) {
+			tbl.Columns = append(tbl.Columns[:i], tbl.Columns[i+1:]...)
+			dropColumnReferences(tbl, name)
+			return true
+		}
+	}
+	return false
+}
+
+// dropTables handles DROP TABLE [IF EXISTS] a, b [CASCADE | RESTRICT]
+func (p *ddlParser) dropTables() error {
+	if err := p.expect("drop", "table"); err != nil {
+		return err
+	}
+	return p.dropNames("table name", func(schemaName, name string) {
+		if tbl := p.cat.FindTable(schemaName, name); tbl != nil {
+			p.cat.RemoveTable(tbl)
+		}
+	})
+}
+
+// dropNames reads the [IF EXISTS] name [, ...] [CASCADE | RESTRICT] part of a
+// DROP statement and calls drop for every name
+func (p *ddlParser) dropNames(what string, drop func(schemaName, name string)) error {
+	p.accept("if", "exists")
+	for {
+		schemaName, name, err := p.qualifiedName(what)
+		if err != nil {
+			return err
+		}
+		drop(schemaName, name)
+		if !p.acceptPunct(",") {
+			break
+		}
+	}
+	p.acceptAny("cascade", "restrict")
+	return p.expectEnd()
+}
+
+// statement is a single SQL statement
+type statement struct {
+	Comments []string // comments found inside or directly before the statement
+	Tokens   []token
+	Pos      position // position of the first token
+	src      string   // source with comments blanked out, which token offsets refer to
+}
+
+// splitStatements splits SQL text into statements on top-level semicolons.
+// Quoted strings, quoted identifiers and dollar-quoted bodies are kept intact,
+// and MySQL also allows # comments and backslash escapes inside strings.
+func splitStatements(sql string, dialect types.Dialect) ([]statement, error) {
+	toks, comments, err := lex(sql, dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	// blank out comments, keeping line breaks so offsets and positions still match
+	masked := []byte(sql)
+	for _, c := range comments {
+		for i := c.start; i < c.end; i++ {
+			if masked[i] != '\n' {
+				masked[i] = ' '
+			}
+		}
+	}
+	src := string(masked)
+
+	var out []statement
+	next := 0 // first comment not yet assigned to a statement
+	begin := 0
+	for i := 0; i <= len(toks); i++ {
+		if i < len(toks) && !toks[i].isPunct(";") {
+			continue
+		}
+		if i > begin {
+			first, last := toks[begin], toks[i-1]
+			st := statement{
+				Tokens: toks[begin:i],
+				Pos:    first.pos,
+				src:    src,
+			}
+			for ; next < len(comments) && comments[next].start < last.end; next++ {
+				st.Comments = append(st.Comments, comments[next].text)
+			}
+			out = append(out, st)
+		}
+		begin = i + 1
+	}
+	return out, nil
+}
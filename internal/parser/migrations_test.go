@@ -0,0 +1,137 @@
+package parser
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+func TestParseFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		reader   MigrationReader
+		tables   []string
+		enums    []string
+		warnings int
+		err      string
+	}{
+		{
+			name: "parent and child tables with a view and an enum",
+			file: "000001_orders.up.sql",
+			content: `CREATE TYPE shop.order_status AS ENUM ('open', 'paid');
+CREATE TABLE shop.orders (id UUID PRIMARY KEY, status shop.order_status NOT NULL);
+CREATE TABLE shop.order_items (id UUID PRIMARY KEY, order_id UUID REFERENCES shop.orders(id));
+CREATE VIEW shop.open_orders AS SELECT id FROM shop.orders WHERE status = 'open';`,
+			reader: golangMigrateReader{},
+			tables: []string{"shop.orders", "shop.order_items", "shop.open_orders"},
+			enums:  []string{"shop.order_status"},
+		},
+		{
+			name:    "down section left out",
+			file:    "00001_orders.sql",
+			content: "-- +goose Up\nCREATE TABLE orders (id INT);\nCREATE TABLE order_items (id INT);\n-- +goose Down\nDROP TABLE order_items;\nDROP TABLE orders;\n",
+			reader:  NewMigrationReader(types.FormatGoose),
+			tables:  []string{".orders", ".order_items"},
+		},
+		{
+			name:     "table of an earlier migration",
+			file:     "000002_email.up.sql",
+			content:  "CREATE TABLE tags (id INT);\nALTER TABLE users ADD COLUMN email TEXT;",
+			reader:   golangMigrateReader{},
+			tables:   []string{".tags"},
+			warnings: 1,
+		},
+		{
+			name:    "syntax error",
+			file:    "000003_broken.up.sql",
+			content: "CREATE TABLE tags (id INT,",
+			reader:  golangMigrateReader{},
+			err:     "000003_broken.up.sql:1:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, map[string]string{tt.file: tt.content})
+			path := filepath.Join(dir, tt.file)
+
+			cat, err := ParseFile(path, types.DialectPostgres, tt.reader)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("ParseFile() error = %v, want %s", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseFile() error = %v", err)
+			}
+
+			var tables, enums []string
+			for _, tbl := range cat.Tables {
+				tables = append(tables, tbl.Schema+"."+tbl.Name)
+				if tbl.Source != path {
+					t.Errorf("%s source = %s, want %s", tbl.Name, tbl.Source, path)
+				}
+			}
+			for _, enum := range cat.Enums {
+				enums = append(enums, enum.Schema+"."+enum.Name)
+			}
+			if !reflect.DeepEqual(tables, tt.tables) {
+				t.Errorf("tables = %q, want %q", tables, tt.tables)
+			}
+			if !reflect.DeepEqual(enums, tt.enums) {
+				t.Errorf("enums = %q, want %q", enums, tt.enums)
+			}
+			if len(cat.Warnings) != tt.warnings {
+				t.Errorf("warnings = %v, want %d", cat.Warnings, tt.warnings)
+			}
+		})
+	}
+}
+
+func TestFindMigration(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"shop/000001_orders.up.sql":  "CREATE TABLE shop.orders (id INT);\nCREATE TABLE shop.order_items (id INT);",
+		"shop/000002_tags.up.sql":    "CREATE TABLE shop.tags (id INT);",
+		"shop/000003_rename.up.sql":  "ALTER TABLE shop.tags RENAME TO labels;",
+		"shop/000004_summary.up.sql": "CREATE VIEW shop.order_summary AS SELECT id FROM shop.orders;",
+	})
+
+	tests := []struct {
+		table string
+		want  string
+		err   string
+	}{
+		{table: "orders", want: "shop/000001_orders.up.sql"},
+		{table: "order_items", want: "shop/000001_orders.up.sql"},
+		{table: "labels", want: "shop/000002_tags.up.sql"},
+		{table: "order_summary", want: "shop/000004_summary.up.sql"},
+		{table: "tags", err: "no migration for shop.tags"},
+		{table: "items", err: "no migration for shop.items"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.table, func(t *testing.T) {
+			got, err := FindMigration(dir, "shop", tt.table, types.DialectPostgres, golangMigrateReader{})
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("FindMigration() error = %v, want %s", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindMigration() error = %v", err)
+			}
+			if want := filepath.Join(dir, filepath.FromSlash(tt.want)); got != want {
+				t.Errorf("FindMigration() = %s, want %s", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// mysqlTableOptions are the MySQL table options that do not change the table shape
+var mysqlTableOptions = map[string]bool{
+	"ENGINE": true, "AUTO_INCREMENT": true, "CHARSET": true, "CHARACTER": true, "COLLATE": true,
+	"ROW_FORMAT": true, "KEY_BLOCK_SIZE": true, "AVG_ROW_LENGTH": true, "CHECKSUM": true,
+	"STATS_PERSISTENT": true, "STATS_AUTO_RECALC": true, "STATS_SAMPLE_PAGES": true,
+	"TABLESPACE": true, "PACK_KEYS": true, "MAX_ROWS": true, "MIN_ROWS": true,
+	"DELAY_KEY_WRITE": true, "INSERT_METHOD": true, "COMPRESSION": true, "ENCRYPTION": true,
+	"DATA": true, "INDEX": true, "CONNECTION": true, "PASSWORD": true, "UNION": true,
+	"SECONDARY_ENGINE": true, "ENGINE_ATTRIBUTE": true, "SECONDARY_ENGINE_ATTRIBUTE": true,
+	"AUTOEXTEND_SIZE": true, "STORAGE": true,
+}
+
+// mysqlTableOption skips a MySQL table option such as ENGINE=InnoDB or
+// DEFAULT CHARSET utf8mb4, reporting options that are not known
+func (p *ddlParser) mysqlTableOption() error {
+	tok := p.peek()
+	p.accept("default")
+	name := strings.ToUpper(p.next().text)
+	if !mysqlTableOptions[name] {
+		p.warnf(tok, "ignoring table option %s", tok.describe())
+	}
+	switch name {
+	case "CHARACTER":
+		p.accept("set")
+	case "DATA", "INDEX":
+		p.accept("directory")
+	}
+
+	p.acceptOperator("=")
+	if p.atPunct("(") {
+		_, err := p.group()
+		return err
+	}
+	if p.atEOF() || p.atPunct(",") {
+		return p.errorf(p.peek(), "expected value of table option %s", name)
+	}
+	p.i++
+	return nil
+}
+
+// alterRedefineColumn handles the MySQL forms MODIFY [COLUMN] <definition>
+// and CHANGE [COLUMN] <old name> <definition>, both optionally followed by
+// FIRST or AFTER <column>
+func (p *ddlParser) alterRedefineColumn(tbl *types.Table) error {
+	change := p.next().is("change")
+	p.accept("column")
+
+	nameTok := p.peek()
+	name := ""
+	if change {
+		var err error
+		if name, err = p.ident("column name"); err != nil {
+			return err
+		}
+	}
+
+	col, inline, err := p.columnDef()
+	if err != nil {
+		return err
+	}
+	if !change {
+		name = col.Name
+	}
+
+	existing := tbl.FindColumn(name)
+	if existing == nil {
+		return p.errorf(nameTok, "column %q of %s does not exist", name, tbl.Name)
+	}
+	oldName := existing.Name
+	*existing = col
+	if !strings.EqualFold(oldName, col.Name) {
+		renameColumnReferences(p.cat, tbl, oldName, col.Name)
+	}
+	for _, c := range inline {
+		p.applyConstraint(tbl, c)
+	}
+
+	if p.atAny("first", "after") {
+		// move the column to its new position
+		dropped := *existing
+		for i := range tbl.Columns {
+			if &tbl.Columns[i] == existing {
+				tbl.Columns = append(tbl.Columns[:i], tbl.Columns[i+1:]...)
+				break
+			}
+		}
+		at, err := p.columnPosition(tbl)
+		if err != nil {
+			return err
+		}
+		insertColumn(tbl, dropped, at)
+	}
+	return nil
+}
+
+// columnPosition reads the MySQL FIRST or AFTER <column> placement of a column
+// and returns the index to insert it at. Without one, columns are appended.
+func (p *ddlParser) columnPosition(tbl *types.Table) (int, error) {
+	if p.accept("first") {
+		return 0, nil
+	}
+	if !p.accept("after") {
+		return len(tbl.Columns), nil
+	}
+	tok := p.peek()
+	name, err := p.ident("column name")
+	if err != nil {
+		return 0, err
+	}
+	for i := range tbl.Columns {
+		if strings.EqualFold(tbl.Columns[i].Name, name) {
+			return i + 1, nil
+		}
+	}
+	return 0, p.errorf(tok, "column %q of %s does not exist", name, tbl.Name)
+}
+
+// renameTables handles the MySQL statement RENAME TABLE a TO b [, c TO d]
+func (p *ddlParser) renameTables() error {
+	if err := p.expect("rename", "table"); err != nil {
+		return err
+	}
+	for {
+		schemaName, tableName, err := p.qualifiedName("table name")
+		if err != nil {
+			return err
+		}
+		if err := p.expect("to"); err != nil {
+			return err
+		}
+		_, newName, err := p.qualifiedName("table name")
+		if err != nil {
+			return err
+		}
+		if tbl := p.cat.FindTable(schemaName, tableName); tbl != nil {
+			renameTableTo(p.cat, tbl, newName)
+		}
+		if !p.acceptPunct(",") {
+			return p.expectEnd()
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package parser
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// MigrationReader understands the file layout of a migration tool: which files
+// under the migrations root are migrations and which part of a file applies it
+type MigrationReader interface {
+	// IsMigration reports whether the file holds a migration
+	IsMigration(path string) bool
+	// Up returns the SQL that applies the migration. Lines of other sections are
+	// blanked rather than removed, so positions in diagnostics match the file.
+	Up(content string) (string, error)
+}
+
+// NewMigrationReader returns the reader for a migration format
+func NewMigrationReader(format types.MigrationFormat) MigrationReader {
+	switch format {
+	case types.FormatGoose:
+		return sectionReader{tool: "goose", up: "-- +goose Up", down: "-- +goose Down"}
+	case types.FormatDbmate:
+		return sectionReader{tool: "dbmate", up: "-- migrate:up", down: "-- migrate:down"}
+	case types.FormatAtlas:
+		return atlasReader{}
+	}
+	return golangMigrateReader{}
+}
+
+// golangMigrateReader reads <version>_<name>.up.sql files; .down.sql files are ignored
+type golangMigrateReader struct{}
+
+func (golangMigrateReader) IsMigration(path string) bool {
+	return strings.HasSuffix(path, ".up.sql")
+}
+
+func (golangMigrateReader) Up(content string) (string, error) {
+	return content, nil
+}
+
+// atlasReader reads the <version>_<name>.sql files of an atlas migration directory.
+// Atlas migrations only hold up SQL; atlas.sum and other files are ignored.
+type atlasReader struct{}
+
+func (atlasReader) IsMigration(path string) bool {
+	return isVersionedSQL(path)
+}
+
+func (atlasReader) Up(content string) (string, error) {
+	return content, nil
+}
+
+// snapshotReader reads a schema snapshot such as a pg_dump file. psql meta-commands
+// like \connect or \restrict are not SQL and are blanked.
+type snapshotReader struct{}
+
+func (snapshotReader) IsMigration(path string) bool {
+	return strings.HasSuffix(path, ".sql")
+}
+
+func (snapshotReader) Up(content string) (string, error) {
+	lines := strings.SplitAfter(content, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "\\") {
+			lines[i] = blankLine(line)
+		}
+	}
+	return strings.Join(lines, ""), nil
+}
+
+// sectionReader reads single-file migrations that mark their up and down SQL
+// with annotation comments, as goose and dbmate do
+type sectionReader struct {
+	tool string
+	up   string // annotation starting the up section
+	down string // annotation starting the down section
+}
+
+func (sectionReader) IsMigration(path string) bool {
+	return isVersionedSQL(path)
+}
+
+func (r sectionReader) Up(content string) (string, error) {
+	lines := strings.SplitAfter(content, "\n")
+	inUp, found := false, false
+	for i, line := range lines {
+		switch {
+		case isAnnotation(line, r.up):
+			inUp, found = true, true
+		case isAnnotation(line, r.down):
+			inUp = false
+		}
+		if !inUp {
+			lines[i] = blankLine(line)
+		}
+	}
+	if !found {
+		return "", fmt.Errorf("missing %q annotation of %s migration", r.up, r.tool)
+	}
+	return strings.Join(lines, ""), nil
+}
+
+// blankLine removes the content of a line but keeps its line break, so line
+// numbers of the following lines stay the same
+func blankLine(line string) string {
+	return line[len(strings.TrimRight(line, "\r\n")):]
+}
+
+// isAnnotation reports whether a line is the given annotation comment, optionally
+// followed by options such as "transaction:false". Annotations are matched
+// case-insensitively and regardless of spacing.
+func isAnnotation(line, annotation string) bool {
+	return matchFields(strings.Fields(strings.ToLower(line)), strings.Fields(strings.ToLower(annotation))...)
+}
+
+// isVersionedSQL reports whether path is a <version>_<name>.sql file
+func isVersionedSQL(path string) bool {
+	base := filepath.Base(path)
+	return strings.HasSuffix(base, ".sql") && leadingDigits(base) != ""
+}
+
+// matchFields reports whether fields start with the given keywords (case-insensitive)
+func matchFields(fields []string, keywords ...string) bool {
+	if len(fields) < len(keywords) {
+		return false
+	}
+	for i, kw := range keywords {
+		if !strings.EqualFold(fields[i], kw) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,219 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// writeFiles creates files under dir, keyed by their slash separated path
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLoadCatalogFormats(t *testing.T) {
+	tests := []struct {
+		name   string
+		format types.MigrationFormat
+		files  map[string]string
+	}{
+		{
+			name:   "golang-migrate",
+			format: types.FormatGolangMigrate,
+			files: map[string]string{
+				"auth/000001_users.up.sql":     "CREATE TABLE auth.users (id UUID PRIMARY KEY, name TEXT);",
+				"auth/000001_users.down.sql":   "DROP TABLE auth.users;",
+				"auth/000002_email.up.sql":     "ALTER TABLE auth.users ADD COLUMN email TEXT;",
+				"auth/000002_email.down.sql":   "ALTER TABLE auth.users DROP COLUMN email;",
+				"auth/000010_bio.up.sql":       "ALTER TABLE auth.users ADD COLUMN bio TEXT;",
+				"auth/000010_bio.down.sql":     "ALTER TABLE auth.users DROP COLUMN bio;",
+				"auth/README.md":               "not a migration",
+				"auth/000003_ignored.down.sql": "DROP TABLE auth.users;",
+			},
+		},
+		{
+			name:   "goose",
+			format: types.FormatGoose,
+			files: map[string]string{
+				"00001_users.sql": "-- +goose Up\nCREATE TABLE auth.users (id UUID PRIMARY KEY, name TEXT);\n\n-- +goose Down\nDROP TABLE auth.users;\n",
+				"00002_email.sql": "-- +goose Up\n-- +goose StatementBegin\nALTER TABLE auth.users ADD COLUMN email TEXT;\n-- +goose StatementEnd\n-- +goose Down\nALTER TABLE auth.users DROP COLUMN email;\n",
+				"00010_bio.sql":   "-- +goose up\nALTER TABLE auth.users ADD COLUMN bio TEXT;\n-- +goose down\nALTER TABLE auth.users DROP COLUMN bio;\n",
+			},
+		},
+		{
+			name:   "dbmate",
+			format: types.FormatDbmate,
+			files: map[string]string{
+				"20240101000000_users.sql": "-- migrate:up\nCREATE TABLE auth.users (id UUID PRIMARY KEY, name TEXT);\n\n-- migrate:down\nDROP TABLE auth.users;\n",
+				"20240102000000_email.sql": "-- migrate:up transaction:false\nALTER TABLE auth.users ADD COLUMN email TEXT;\n-- migrate:down\nALTER TABLE auth.users DROP COLUMN email;\n",
+				"20240110000000_bio.sql":   "-- migrate:up\nALTER TABLE auth.users ADD COLUMN bio TEXT;\n-- migrate:down\nALTER TABLE auth.users DROP COLUMN bio;\n",
+			},
+		},
+		{
+			name:   "atlas",
+			format: types.FormatAtlas,
+			files: map[string]string{
+				"20240101000000_users.sql": "CREATE TABLE auth.users (id UUID PRIMARY KEY, name TEXT);",
+				"20240102000000_email.sql": "ALTER TABLE auth.users ADD COLUMN email TEXT;",
+				"20240110000000_bio.sql":   "ALTER TABLE auth.users ADD COLUMN bio TEXT;",
+				"atlas.sum":                "h1:abc=",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+
+			cat, err := LoadCatalog(dir, types.DialectPostgres, NewMigrationReader(tt.format))
+			if err != nil {
+				t.Fatalf("LoadCatalog() error = %v", err)
+			}
+			tbl := cat.FindTable("auth", "users")
+			if tbl == nil {
+				t.Fatal("auth.users not found")
+			}
+			if want := []string{"id", "name", "email", "bio"}; !reflect.DeepEqual(columnNames(tbl), want) {
+				t.Errorf("columns = %q, want %q", columnNames(tbl), want)
+			}
+		})
+	}
+}
+
+func TestSectionReaderUp(t *testing.T) {
+	reader := NewMigrationReader(types.FormatGoose)
+
+	up, err := reader.Up("-- comment\n-- +goose Up\nCREATE TABLE t (id INT);\n-- +goose Down\nDROP TABLE t;\n")
+	if err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	// other sections are blanked, so line numbers still match the file
+	if want := "\n-- +goose Up\nCREATE TABLE t (id INT);\n\n\n"; up != want {
+		t.Errorf("Up() = %q, want %q", up, want)
+	}
+
+	if _, err := reader.Up("CREATE TABLE t (id INT);\n"); err == nil || !strings.Contains(err.Error(), `missing "-- +goose Up" annotation`) {
+		t.Errorf("Up() without annotation error = %v", err)
+	}
+}
+
+func TestMigrationErrorPosition(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"00001_users.sql": "-- migrate:down\nDROP TABLE users;\n\n-- migrate:up\nCREATE TABLE users (\n  id INT,\n  name TEXT DEFAULT\n);\n",
+	})
+
+	_, err := LoadCatalog(dir, types.DialectPostgres, NewMigrationReader(types.FormatDbmate))
+	if err == nil {
+		t.Fatal("LoadCatalog() error = nil")
+	}
+	want := filepath.Join(dir, "00001_users.sql") + `:8:1: expected expression after DEFAULT, found ")"`
+	if err.Error() != want {
+		t.Errorf("LoadCatalog() error = %q, want %q", err, want)
+	}
+}
+
+func TestListMigrationsOrder(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"2_b.up.sql":   "",
+		"10_c.up.sql":  "",
+		"1_a.up.sql":   "",
+		"sub/3.up.sql": "",
+	})
+
+	files, err := ListMigrations(dir, NewMigrationReader(types.FormatGolangMigrate))
+	if err != nil {
+		t.Fatalf("ListMigrations() error = %v", err)
+	}
+	var got []string
+	for _, f := range files {
+		got = append(got, filepath.Base(f))
+	}
+	if want := []string{"1_a.up.sql", "2_b.up.sql", "3.up.sql", "10_c.up.sql"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListMigrations() = %q, want %q", got, want)
+	}
+}
+
+func TestLoadSchemaFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"schema.sql": `\restrict Qb9sXyZ
+
+SET statement_timeout = 0;
+SELECT pg_catalog.set_config('search_path', '', false);
+
+CREATE SCHEMA auth;
+
+CREATE TYPE auth.user_status AS ENUM (
+    'active',
+    'suspended'
+);
+
+CREATE TABLE auth.members (
+    id bigint NOT NULL,
+    email character varying(255) NOT NULL,
+    status auth.user_status DEFAULT 'active'::auth.user_status NOT NULL
+);
+
+ALTER TABLE auth.members OWNER TO postgres;
+
+CREATE SEQUENCE auth.members_id_seq START WITH 1 INCREMENT BY 1 NO MINVALUE NO MAXVALUE CACHE 1;
+
+ALTER SEQUENCE auth.members_id_seq OWNED BY auth.members.id;
+
+ALTER TABLE ONLY auth.members ALTER COLUMN id SET DEFAULT nextval('auth.members_id_seq'::regclass);
+
+ALTER TABLE ONLY auth.members
+    ADD CONSTRAINT members_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY auth.members
+    ADD CONSTRAINT members_email_key UNIQUE (email);
+
+COMMENT ON COLUMN auth.members.email IS 'Login address';
+
+\unrestrict Qb9sXyZ
+`})
+
+	cat, err := LoadSchemaFile(filepath.Join(dir, "schema.sql"), types.DialectPostgres)
+	if err != nil {
+		t.Fatalf("LoadSchemaFile() error = %v", err)
+	}
+	if len(cat.Warnings) != 0 {
+		t.Errorf("warnings = %v, want none", cat.Warnings)
+	}
+	tbl := cat.FindTable("auth", "members")
+	if tbl == nil {
+		t.Fatal("auth.members not found")
+	}
+
+	id := mustColumn(t, tbl, "id")
+	if !id.PrimaryKey || !id.Identity {
+		t.Errorf("id = %+v, want a sequence backed primary key", id)
+	}
+	email := mustColumn(t, tbl, "email")
+	if email.Length != 255 || email.Comment != "Login address" {
+		t.Errorf("email = %+v, want a commented VARCHAR(255)", email)
+	}
+	status := mustColumn(t, tbl, "status")
+	if status.Enum == nil || status.Default != "'active'" {
+		t.Errorf("status = %+v, want the user_status enum defaulting to 'active'", status)
+	}
+	wantIndexes := []types.Index{{Name: "members_email_key", Columns: []string{"email"}, Unique: true}}
+	if !reflect.DeepEqual(tbl.Indexes, wantIndexes) {
+		t.Errorf("indexes = %+v, want %+v", tbl.Indexes, wantIndexes)
+	}
+}
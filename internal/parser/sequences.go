@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// sequence handles CREATE SEQUENCE and ALTER SEQUENCE. A sequence OWNED BY a
+// column makes it a serial column: pg_dump writes SERIAL columns out as a plain
+// integer column, an owned sequence and a nextval default.
+func (p *ddlParser) sequence() error {
+	p.next() // CREATE or ALTER
+	if err := p.expect("sequence"); err != nil {
+		return err
+	}
+	if !p.accept("if", "not", "exists") {
+		p.accept("if", "exists")
+	}
+	if _, err := p.dottedName("sequence name"); err != nil {
+		return err
+	}
+
+	for !p.atEOF() {
+		if !p.accept("owned", "by") {
+			p.i++
+			continue
+		}
+		if p.accept("none") {
+			continue
+		}
+		tok := p.peek()
+		parts, err := p.dottedName("column name")
+		if err != nil {
+			return err
+		}
+		if len(parts) < 2 {
+			return p.errorf(tok, "expected table.column after OWNED BY")
+		}
+		schemaName, tableName := "", parts[len(parts)-2]
+		if len(parts) > 2 {
+			schemaName = parts[len(parts)-3]
+		}
+		tbl := p.cat.FindTable(schemaName, tableName)
+		if tbl == nil {
+			p.warnf(tok, "ignoring OWNED BY %s: table is not created by an earlier statement", tableName)
+			continue
+		}
+		col := tbl.FindColumn(parts[len(parts)-1])
+		if col == nil {
+			return p.errorf(tok, "column %q of %s does not exist", parts[len(parts)-1], tbl.Name)
+		}
+		col.Identity = true
+		setSerialDefault(col)
+	}
+	return nil
+}
+
+// setSerialDefault drops the nextval default of a serial column, which is
+// implied by the column being an identity like a SERIAL column in a migration
+func setSerialDefault(col *types.Column) {
+	if col.Identity && isNextval(col.Default) {
+		col.Default = ""
+	}
+}
+
+// isNextval reports whether a default draws from a sequence, e.g. nextval('users_id_seq'::regclass)
+func isNextval(expr string) bool {
+	return strings.HasPrefix(strings.ToLower(expr), "nextval(")
+}
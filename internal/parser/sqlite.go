@@ -0,0 +1,40 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// sqliteSchema drops the main and temp database qualifiers, which name the
+// connection's own database rather than a schema
+func sqliteSchema(schemaName string) string {
+	switch strings.ToLower(schemaName) {
+	case "main", "temp":
+		return ""
+	}
+	return schemaName
+}
+
+// setRowIDAlias marks an INTEGER PRIMARY KEY as an identity column: unless the table
+// is WITHOUT ROWID, it aliases the rowid and is assigned by SQLite on insert
+func setRowIDAlias(tbl *types.Table) {
+	if tbl.WithoutRowID {
+		return
+	}
+
+	var pk *types.Column
+	for i := range tbl.Columns {
+		if !tbl.Columns[i].PrimaryKey {
+			continue
+		}
+		if pk != nil {
+			// composite keys do not alias the rowid
+			return
+		}
+		pk = &tbl.Columns[i]
+	}
+	if pk != nil && pk.Type == "INTEGER" {
+		pk.Identity = true
+	}
+}
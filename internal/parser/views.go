@@ -0,0 +1,700 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// typeHint is the comment tag that sets the type of a view column,
+// e.g. "-- @type total NUMERIC(12,2) NOT NULL"
+const typeHint = "type"
+
+// viewModifiers may come between CREATE [OR REPLACE] and VIEW
+var viewModifiers = []string{"temp", "temporary", "materialized", "recursive"}
+
+// fromClauseEnd are the clauses that can follow a FROM clause
+var fromClauseEnd = []string{"where", "group", "having", "window", "order", "limit", "offset",
+	"union", "intersect", "except", "with", "fetch", "for"}
+
+// viewSource is a table (or subquery) referenced in the FROM clause of a view
+type viewSource struct {
+	alias    string
+	table    *types.Table
+	nullable bool // true when the source is on the outer side of a join
+}
+
+// createView parses CREATE [OR REPLACE] [TEMP|MATERIALIZED|RECURSIVE] VIEW
+// [IF NOT EXISTS] name [(columns)] [WITH (options)] AS query. Columns are
+// inferred from the select list using the tables already in the catalog;
+// "@type" comments override the inferred type of a column.
+func (p *ddlParser) createView() (*types.Table, error) {
+	if err := p.expect("create"); err != nil {
+		return nil, err
+	}
+	p.accept("or", "replace")
+	for p.acceptAny(viewModifiers...) {
+	}
+	if err := p.expect("view"); err != nil {
+		return nil, err
+	}
+	p.accept("if", "not", "exists")
+	schemaName, viewName, err := p.qualifiedName("view name")
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	if p.atPunct("(") {
+		if names, err = p.identList("column name"); err != nil {
+			return nil, err
+		}
+	}
+	if p.accept("with") {
+		if _, err := p.group(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expect("as"); err != nil {
+		return nil, err
+	}
+
+	tbl := newTable(schemaName, viewName)
+	tbl.IsView = true
+
+	columns, err := p.viewColumns(viewName, schemaName, p.toks[p.i:])
+	if err != nil {
+		return nil, err
+	}
+	for i, name := range names {
+		if i < len(columns) {
+			columns[i].Name = name
+		}
+	}
+	p.applyTypeHints(columns)
+
+	tbl.Columns = columns
+	return tbl, nil
+}
+
+// viewColumns derives the columns of a view from the select list of its query
+func (p *ddlParser) viewColumns(viewName, schema string, query []token) ([]types.Column, error) {
+	sel := topLevelIndex(query, "select")
+	if sel == -1 {
+		tok := p.peek()
+		return nil, p.errorf(tok, "view %s: expected SELECT, found %s", viewName, tok.describe())
+	}
+	body := query[sel+1:]
+
+	list := body
+	var sources []viewSource
+	if from := topLevelIndex(body, "from"); from != -1 {
+		list = body[:from]
+		sources = p.fromClause(schema, body[from+1:])
+	} else if with := topLevelIndex(body, "with"); with != -1 {
+		// SELECT without FROM followed by WITH [NO] DATA
+		list = body[:with]
+	}
+	list = trimDistinct(list)
+
+	var columns []types.Column
+	for i, item := range splitTopLevel(list, ",") {
+		expr, alias := splitSelectAlias(item)
+		if len(expr) == 0 {
+			continue
+		}
+
+		// expand "*" and "alias.*"
+		if qualifier, ok := starQualifier(expr); ok {
+			for si, src := range sources {
+				if src.table == nil || (qualifier != "" && !strings.EqualFold(src.alias, qualifier)) {
+					continue
+				}
+				for _, c := range src.table.Columns {
+					columns = append(columns, viewColumn(c, src, si == 0))
+				}
+			}
+			continue
+		}
+
+		col := p.inferExpression(expr, sources)
+		switch {
+		case alias != "":
+			col.Name = alias
+		case col.Name == "":
+			col.Name = fmt.Sprintf("column_%d", i+1)
+		}
+		columns = append(columns, col)
+	}
+	if len(columns) == 0 {
+		return nil, p.errorf(query[sel], "view %s: could not infer any columns", viewName)
+	}
+	return columns, nil
+}
+
+// inferExpression infers the name, type and nullability of a select expression
+func (p *ddlParser) inferExpression(expr []token, sources []viewSource) types.Column {
+	if len(expr) == 0 {
+		return types.Column{Type: "TEXT", Nullable: true}
+	}
+
+	// explicit casts win: expr::type or CAST(expr AS type). A converted value
+	// is no longer the key of the source table.
+	if idx := lastTopLevel(expr, func(tok token) bool { return tok.kind == tokOperator && tok.text == "::" }); idx != -1 {
+		col := p.inferExpression(expr[:idx], sources)
+		col.Type = p.castType(expr[idx+1:])
+		col.PrimaryKey = false
+		setTypeModifiers(&col)
+		return col
+	}
+	fn, args := functionCall(expr)
+	if fn == "cast" {
+		if as := lastTopLevel(args, func(tok token) bool { return tok.is("as") }); as != -1 {
+			col := p.inferExpression(args[:as], sources)
+			col.Type = p.castType(args[as+1:])
+			col.PrimaryKey = false
+			setTypeModifiers(&col)
+			return col
+		}
+	}
+
+	// column reference: col or alias.col. Niladic functions such as
+	// current_date look like one.
+	if qualifier, name, ok := columnRef(expr); ok && fn == "" {
+		for si, src := range sources {
+			if src.table == nil || (qualifier != "" && !strings.EqualFold(src.alias, qualifier)) {
+				continue
+			}
+			if c := src.table.FindColumn(name); c != nil {
+				return viewColumn(*c, src, si == 0)
+			}
+		}
+		return types.Column{Name: name, Type: "TEXT", Nullable: true}
+	}
+
+	// literals, optionally signed numbers
+	first := expr[0]
+	switch {
+	case len(expr) == 1 && first.kind == tokString:
+		return types.Column{Type: "TEXT"}
+	case len(expr) == 1 && (first.is("true") || first.is("false")):
+		return types.Column{Type: "BOOLEAN"}
+	case len(expr) == 1 && first.is("null"):
+		return types.Column{Type: "TEXT", Nullable: true}
+	}
+	number := expr
+	if len(number) == 2 && first.kind == tokOperator && (first.text == "-" || first.text == "+") {
+		number = number[1:]
+	}
+	if len(number) == 1 && number[0].kind == tokNumber {
+		if _, err := strconv.ParseInt(number[0].text, 10, 64); err == nil {
+			return types.Column{Type: "INTEGER"}
+		}
+		return types.Column{Type: "NUMERIC"}
+	}
+
+	// CASE takes the type of its first branch
+	if first.is("case") {
+		if then := topLevelIndex(expr, "then"); then != -1 {
+			branch := expr[then+1:]
+			for _, kw := range []string{"when", "else", "end"} {
+				if idx := topLevelIndex(branch, kw); idx != -1 {
+					branch = branch[:idx]
+				}
+			}
+			col := p.inferExpression(branch, sources)
+			col.Name = ""
+			col.PrimaryKey = false
+			col.Nullable = true
+			return col
+		}
+	}
+
+	col := types.Column{Name: fn, Type: "TEXT", Nullable: true}
+	switch fn {
+	case "count", "row_number", "rank", "dense_rank":
+		col.Type, col.Nullable = "BIGINT", false
+	case "sum", "avg":
+		col.Type = "NUMERIC"
+	case "now", "current_timestamp", "date_trunc":
+		col.Type = "TIMESTAMPTZ"
+	case "localtimestamp":
+		col.Type = "TIMESTAMP"
+	case "current_date":
+		col.Type = "DATE"
+	case "bool_and", "bool_or", "every", "exists":
+		col.Type = "BOOLEAN"
+	case "json_agg", "jsonb_agg", "json_build_object", "jsonb_build_object", "to_json", "to_jsonb":
+		col.Type = "JSONB"
+	case "min", "max", "coalesce", "nullif", "greatest", "least":
+		// these return the type of their first argument
+		if parts := splitTopLevel(args, ","); len(parts) > 0 {
+			first := p.inferExpression(parts[0], sources)
+			col.Type, col.Length, col.Precision, col.Scale, col.Enum = first.Type, first.Length, first.Precision, first.Scale, first.Enum
+			if fn == "coalesce" && len(parts) > 1 {
+				col.Nullable = p.inferExpression(parts[len(parts)-1], sources).Nullable
+			}
+		}
+	}
+	return col
+}
+
+// castType reads the target type of a cast the way column types are read, so
+// numeric(12, 2) becomes NUMERIC(12,2)
+func (p *ddlParser) castType(toks []token) string {
+	sub := p.sub(toks)
+	var col types.Column
+	if typ, err := sub.dataType(&col); err == nil && typ != "" && sub.atEOF() {
+		return typ
+	}
+	if len(toks) == 0 {
+		return ""
+	}
+	return strings.ToUpper(p.st.src[toks[0].start:toks[len(toks)-1].end])
+}
+
+// viewColumn copies the shape of a source column into a view column. Only
+// the leading FROM table keeps its primary key, so finders can look rows up by id.
+func viewColumn(c types.Column, src viewSource, primary bool) types.Column {
+	return types.Column{
+		Name:       c.Name,
+		Type:       c.Type,
+		Nullable:   c.Nullable || src.nullable,
+		PrimaryKey: c.PrimaryKey && primary && !src.nullable,
+		Length:     c.Length,
+		Precision:  c.Precision,
+		Scale:      c.Scale,
+		Enum:       c.Enum,
+	}
+}
+
+// applyTypeHints applies "@type column TYPE [NOT NULL|NULL]" comments of the
+// statement to the columns. Hints that do not parse are left out.
+func (p *ddlParser) applyTypeHints(columns []types.Column) {
+	for _, comment := range p.st.Comments {
+		for _, line := range strings.Split(comment, "\n") {
+			line = strings.TrimLeft(strings.TrimSpace(line), "* \t")
+			toks, _, err := lex(line, p.dialect)
+			if err != nil || len(toks) < 3 || toks[0].text != "@" || !toks[1].is(typeHint) || toks[1].start != toks[0].end {
+				continue
+			}
+
+			hint := newDDLParser(p.cat, p.file, statement{Tokens: toks[2:], src: line}, p.dialect)
+			name, err := hint.ident("column name")
+			if err != nil {
+				continue
+			}
+			var typed types.Column
+			typ, err := hint.dataType(&typed)
+			if err != nil || typ == "" {
+				continue
+			}
+			nullable := !hint.accept("not", "null")
+			if nullable {
+				hint.accept("null")
+			}
+			if !hint.atEOF() {
+				continue
+			}
+
+			for i := range columns {
+				col := &columns[i]
+				if !strings.EqualFold(col.Name, name) {
+					continue
+				}
+				col.Type = typ
+				col.Nullable = nullable
+				col.Length, col.Precision, col.Scale, col.Enum = 0, 0, 0, nil
+				setTypeModifiers(col)
+			}
+		}
+	}
+}
+
+// fromClause resolves the tables of a FROM clause and their aliases.
+// Tables on the outer side of LEFT, RIGHT and FULL joins are marked nullable.
+func (p *ddlParser) fromClause(schema string, toks []token) []viewSource {
+	// the FROM clause ends at the first clause that can follow it
+	topLevel(toks, func(i int) bool {
+		for _, kw := range fromClauseEnd {
+			if toks[i].is(kw) {
+				toks = toks[:i]
+				return false
+			}
+		}
+		return true
+	})
+
+	var sources []viewSource
+	expectSource := true
+	outer := ""
+	for i := 0; i < len(toks); i++ {
+		tok := toks[i]
+		if tok.isPunct(",") {
+			expectSource, outer = true, ""
+			continue
+		}
+		// LEFT and RIGHT are also functions, e.g. in a join condition
+		call := i+1 < len(toks) && toks[i+1].isPunct("(") && (tok.is("left") || tok.is("right"))
+		if tok.kind == tokWord && !call {
+			switch kw := strings.ToLower(tok.text); kw {
+			case "left", "right", "full":
+				outer = kw
+				continue
+			case "join":
+				expectSource = true
+				continue
+			case "on", "using":
+				expectSource = false
+				continue
+			case "inner", "cross", "natural", "outer", "lateral", "only":
+				continue
+			}
+		}
+		if !expectSource {
+			// join conditions, skipping parentheses as a whole
+			if tok.isPunct("(") {
+				i = closing(toks, i)
+			}
+			continue
+		}
+		expectSource = false
+
+		src := viewSource{}
+		if tok.isPunct("(") {
+			end := closing(toks, i)
+			inner := toks[i+1 : end]
+			i = end
+			// a parenthesized join, as pg_dump writes them: FROM (a JOIN b ON ...)
+			if isJoinGroup(inner) {
+				group := p.fromClause(schema, inner)
+				sources = joinSources(sources, group, outer)
+				outer = ""
+				continue
+			}
+			// a subquery, whose columns are not known
+		} else {
+			parts := []string{tok.value}
+			for i+2 < len(toks) && toks[i+1].isPunct(".") && isIdentToken(toks[i+2]) {
+				parts = append(parts, toks[i+2].value)
+				i += 2
+			}
+			src.alias = parts[len(parts)-1]
+			if i+1 < len(toks) && toks[i+1].isPunct("(") {
+				// a set returning function
+				i = closing(toks, i+1)
+			} else {
+				srcSchema := schema
+				if len(parts) > 1 {
+					srcSchema = parts[len(parts)-2]
+				}
+				src.table = p.cat.FindTable(srcSchema, src.alias)
+			}
+		}
+
+		// optional [AS] alias [(column aliases)]
+		if i+1 < len(toks) && toks[i+1].is("as") {
+			i++
+		}
+		if i+1 < len(toks) && isIdentToken(toks[i+1]) && !isFromKeyword(toks[i+1]) {
+			i++
+			src.alias = toks[i].value
+			if i+1 < len(toks) && toks[i+1].isPunct("(") {
+				i = closing(toks, i+1)
+			}
+		}
+
+		sources = joinSources(sources, []viewSource{src}, outer)
+		outer = ""
+	}
+	return sources
+}
+
+// joinSources appends the sources of a joined table or join group. The outer
+// side of a LEFT, RIGHT or FULL join becomes nullable.
+func joinSources(sources, joined []viewSource, outer string) []viewSource {
+	if outer == "left" || outer == "full" {
+		for j := range joined {
+			joined[j].nullable = true
+		}
+	}
+	if outer == "right" || outer == "full" {
+		for j := range sources {
+			sources[j].nullable = true
+		}
+	}
+	return append(sources, joined...)
+}
+
+// isJoinGroup reports whether the content of a parenthesized FROM item is a
+// join such as "a JOIN b ON ...". Subqueries are not join groups.
+func isJoinGroup(inner []token) bool {
+	if len(inner) == 0 {
+		return false
+	}
+	for _, kw := range []string{"select", "with", "values", "table"} {
+		if inner[0].is(kw) {
+			return false
+		}
+	}
+	return true
+}
+
+func isFromKeyword(tok token) bool {
+	for _, kw := range []string{"join", "left", "right", "full", "inner", "cross", "natural", "outer", "on", "using", "lateral"} {
+		if tok.is(kw) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitSelectAlias splits "expr [AS] alias" into the expression and alias
+func splitSelectAlias(item []token) ([]token, string) {
+	n := len(item)
+	if as := lastTopLevel(item, func(tok token) bool { return tok.is("as") }); as != -1 && as == n-2 {
+		if alias := item[n-1]; isIdentToken(alias) || alias.kind == tokString {
+			return item[:as], alias.value
+		}
+	}
+
+	// implicit alias: "expr alias" where expr ends with an identifier, literal,
+	// ')' or the END of a CASE
+	if n >= 2 {
+		last, prev := item[n-1], item[n-2]
+		endsExpr := prev.kind == tokQuoted || prev.kind == tokString || prev.kind == tokNumber || prev.isPunct(")") ||
+			prev.kind == tokWord && (!isReservedWord(prev.text) || endsExpression(prev.text))
+		if isIdentToken(last) && !(last.kind == tokWord && isReservedWord(last.text)) && endsExpr {
+			return item[:n-1], last.value
+		}
+	}
+	return item, ""
+}
+
+func isReservedWord(s string) bool {
+	switch strings.ToLower(s) {
+	case "and", "or", "not", "is", "null", "true", "false", "end", "then", "else", "when", "case",
+		"in", "like", "ilike", "between", "distinct", "from":
+		return true
+	}
+	return false
+}
+
+// endsExpression reports whether a reserved word can end an expression, as the
+// END of a CASE and the NULL, TRUE and FALSE literals do
+func endsExpression(s string) bool {
+	switch strings.ToLower(s) {
+	case "end", "null", "true", "false":
+		return true
+	}
+	return false
+}
+
+// trimDistinct removes a leading DISTINCT, DISTINCT ON (...) or ALL from a select list
+func trimDistinct(list []token) []token {
+	switch {
+	case len(list) > 0 && list[0].is("all"):
+		return list[1:]
+	case len(list) > 0 && list[0].is("distinct"):
+		list = list[1:]
+		if len(list) > 1 && list[0].is("on") && list[1].isPunct("(") {
+			return list[closing(list, 1)+1:]
+		}
+	}
+	return list
+}
+
+// starQualifier reports whether expr is "*" or "alias.*" and returns the alias
+func starQualifier(expr []token) (string, bool) {
+	n := len(expr)
+	if n == 0 || expr[n-1].kind != tokOperator || expr[n-1].text != "*" {
+		return "", false
+	}
+	if n == 1 {
+		return "", true
+	}
+	if n >= 3 && expr[n-2].isPunct(".") && isIdentToken(expr[n-3]) {
+		return expr[n-3].value, true
+	}
+	return "", false
+}
+
+// columnRef reports whether expr is a (possibly qualified) column name and
+// returns its qualifier and name
+func columnRef(expr []token) (string, string, bool) {
+	if len(expr)%2 == 0 {
+		return "", "", false
+	}
+	for i, tok := range expr {
+		if i%2 == 1 {
+			if !tok.isPunct(".") {
+				return "", "", false
+			}
+		} else if !isIdentToken(tok) || tok.kind == tokWord && isReservedWord(tok.text) {
+			return "", "", false
+		}
+	}
+	name := expr[len(expr)-1].value
+	if len(expr) == 1 {
+		return "", name, true
+	}
+	return expr[len(expr)-3].value, name, true
+}
+
+// isIdentToken reports whether tok is a bare or quoted identifier
+func isIdentToken(tok token) bool {
+	return tok.kind == tokWord || tok.kind == tokQuoted
+}
+
+// functionCall returns the lower-cased name and the argument tokens of a call
+// expression, or "" if expr is not a call. Niladic functions such as now and
+// current_date are recognised without parentheses.
+func functionCall(expr []token) (string, []token) {
+	if len(expr) == 1 {
+		for _, fn := range []string{"current_timestamp", "current_date", "localtimestamp"} {
+			if expr[0].is(fn) {
+				return fn, nil
+			}
+		}
+	}
+
+	// [schema.]name(args), possibly followed by FILTER or OVER clauses
+	i := 0
+	for i+2 < len(expr) && isIdentToken(expr[i]) && expr[i+1].isPunct(".") {
+		i += 2
+	}
+	if i+1 >= len(expr) || !isIdentToken(expr[i]) || !expr[i+1].isPunct("(") || !expr[len(expr)-1].isPunct(")") {
+		return "", nil
+	}
+	return strings.ToLower(expr[i].value), expr[i+2 : closing(expr, i+1)]
+}
+
+// topLevel calls fn with the index of every token of toks outside parentheses
+// and brackets. Scanning stops when fn returns false.
+func topLevel(toks []token, fn func(i int) bool) {
+	depth := 0
+	for i, tok := range toks {
+		switch {
+		case tok.isPunct("(") || tok.isPunct("["):
+			depth++
+		case tok.isPunct(")") || tok.isPunct("]"):
+			depth--
+		case depth == 0:
+			if !fn(i) {
+				return
+			}
+		}
+	}
+}
+
+// topLevelIndex returns the index of the first keyword of toks outside
+// parentheses, or -1
+func topLevelIndex(toks []token, keyword string) int {
+	found := -1
+	topLevel(toks, func(i int) bool {
+		if toks[i].is(keyword) {
+			found = i
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// lastTopLevel returns the index of the last token of toks outside parentheses
+// that matches, or -1
+func lastTopLevel(toks []token, match func(token) bool) int {
+	found := -1
+	topLevel(toks, func(i int) bool {
+		if match(toks[i]) {
+			found = i
+		}
+		return true
+	})
+	return found
+}
+
+// splitTopLevel splits toks on the punctuation sep outside parentheses
+func splitTopLevel(toks []token, sep string) [][]token {
+	var out [][]token
+	start := 0
+	topLevel(toks, func(i int) bool {
+		if toks[i].isPunct(sep) {
+			out = append(out, toks[start:i])
+			start = i + 1
+		}
+		return true
+	})
+	if start < len(toks) {
+		out = append(out, toks[start:])
+	}
+	return out
+}
+
+// closing returns the index of the parenthesis closing the one at toks[open],
+// or the last index when it is not closed
+func closing(toks []token, open int) int {
+	depth := 0
+	for i := open; i < len(toks); i++ {
+		switch {
+		case toks[i].isPunct("("):
+			depth++
+		case toks[i].isPunct(")"):
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(toks) - 1
+}
+
+// dropViews handles DROP [MATERIALIZED] VIEW [IF EXISTS] a, b [CASCADE | RESTRICT]
+func (p *ddlParser) dropViews() error {
+	if err := p.expect("drop"); err != nil {
+		return err
+	}
+	p.accept("materialized")
+	if err := p.expect("view"); err != nil {
+		return err
+	}
+	return p.dropNames("view name", func(schemaName, name string) {
+		if tbl := p.cat.FindTable(schemaName, name); tbl != nil && tbl.IsView {
+			p.cat.RemoveTable(tbl)
+		}
+	})
+}
+
+// alterView handles RENAME TO, RENAME COLUMN and SET SCHEMA on views.
+// Other actions, such as OWNER TO, do not change the view shape.
+func (p *ddlParser) alterView() error {
+	if err := p.expect("alter"); err != nil {
+		return err
+	}
+	p.accept("materialized")
+	if err := p.expect("view"); err != nil {
+		return err
+	}
+	p.accept("if", "exists")
+	schemaName, viewName, err := p.qualifiedName("view name")
+	if err != nil {
+		return err
+	}
+	tbl := p.cat.FindTable(schemaName, viewName)
+	if tbl == nil || !tbl.IsView {
+		return nil
+	}
+
+	switch {
+	case p.accept("rename"):
+		return p.alterRename(tbl)
+	case p.accept("set", "schema"):
+		name, err := p.ident("schema name")
+		if err != nil {
+			return err
+		}
+		tbl.Schema = name
+	}
+	return nil
+}
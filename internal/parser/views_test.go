@@ -0,0 +1,234 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rifqiakrm/starter-cli/internal/types"
+)
+
+// viewTables are the tables the views of the tests select from
+const viewTables = `
+CREATE TABLE users (id UUID PRIMARY KEY, email VARCHAR(255) NOT NULL, name TEXT);
+CREATE TABLE orders (id UUID PRIMARY KEY, user_id UUID NOT NULL REFERENCES users (id), total NUMERIC(12,2) NOT NULL, created_at TIMESTAMPTZ NOT NULL);
+`
+
+// viewCol is the shape of a view column the tests compare
+type viewCol struct {
+	Name       string
+	Type       string
+	Nullable   bool
+	PrimaryKey bool
+}
+
+func TestCreateView(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want []viewCol
+	}{
+		{
+			name: "star",
+			sql:  "CREATE VIEW v AS SELECT * FROM users",
+			want: []viewCol{{"id", "UUID", false, true}, {"email", "VARCHAR(255)", false, false}, {"name", "TEXT", true, false}},
+		},
+		{
+			name: "qualified star",
+			sql:  "CREATE VIEW v AS SELECT o.*, u.email FROM orders o JOIN users u ON u.id = o.user_id",
+			want: []viewCol{
+				{"id", "UUID", false, true}, {"user_id", "UUID", false, false}, {"total", "NUMERIC(12,2)", false, false},
+				{"created_at", "TIMESTAMPTZ", false, false}, {"email", "VARCHAR(255)", false, false},
+			},
+		},
+		{
+			name: "explicit and implicit aliases",
+			sql:  `CREATE VIEW v AS SELECT u.email AS login, u.name display_name, "u"."id" AS "key", 'x' label, 1 + 2 FROM users AS u`,
+			want: []viewCol{
+				{"login", "VARCHAR(255)", false, false}, {"display_name", "TEXT", true, false}, {"key", "UUID", false, true},
+				{"label", "TEXT", false, false}, {"column_5", "TEXT", true, false},
+			},
+		},
+		{
+			name: "column list",
+			sql:  "CREATE VIEW v (user_id, login) AS SELECT id, email FROM users",
+			want: []viewCol{{"user_id", "UUID", false, true}, {"login", "VARCHAR(255)", false, false}},
+		},
+		{
+			name: "left join",
+			sql:  "CREATE VIEW v AS SELECT u.id, o.total FROM users u LEFT JOIN orders o ON o.user_id = u.id",
+			want: []viewCol{{"id", "UUID", false, true}, {"total", "NUMERIC(12,2)", true, false}},
+		},
+		{
+			name: "right join",
+			sql:  "CREATE VIEW v AS SELECT u.id AS user_id, o.id FROM users u RIGHT OUTER JOIN orders o ON o.user_id = u.id",
+			want: []viewCol{{"user_id", "UUID", true, false}, {"id", "UUID", false, false}},
+		},
+		{
+			name: "full join",
+			sql:  "CREATE VIEW v AS SELECT u.email, o.total FROM users u FULL JOIN orders o ON o.user_id = u.id",
+			want: []viewCol{{"email", "VARCHAR(255)", true, false}, {"total", "NUMERIC(12,2)", true, false}},
+		},
+		{
+			name: "parenthesized join group",
+			sql: "CREATE VIEW v AS SELECT o.id, u.email, x.total FROM orders o " +
+				"LEFT JOIN (users u JOIN orders x ON x.user_id = u.id) ON u.id = o.user_id",
+			want: []viewCol{{"id", "UUID", false, true}, {"email", "VARCHAR(255)", true, false}, {"total", "NUMERIC(12,2)", true, false}},
+		},
+		{
+			name: "pg_dump join group",
+			sql:  "CREATE VIEW v AS SELECT u.email, o.total FROM (users u LEFT JOIN orders o ON ((o.user_id = u.id)))",
+			want: []viewCol{{"email", "VARCHAR(255)", false, false}, {"total", "NUMERIC(12,2)", true, false}},
+		},
+		{
+			name: "casts",
+			sql: "CREATE VIEW v AS SELECT o.total::text AS total_text, CAST(o.created_at AS date) AS day, " +
+				"o.id::varchar(36) id_text, o.total::numeric(10,2) FROM orders o",
+			want: []viewCol{
+				{"total_text", "TEXT", false, false}, {"day", "DATE", false, false},
+				{"id_text", "VARCHAR(36)", false, false}, {"total", "NUMERIC(10,2)", false, false},
+			},
+		},
+		{
+			name: "functions",
+			sql: "CREATE VIEW v AS SELECT now() AS at, current_date AS today, CURRENT_TIMESTAMP AS ts, " +
+				"count(*) AS n, max(o.created_at) AS last_at, coalesce(o.total, 0) AS amount FROM orders o GROUP BY o.user_id",
+			want: []viewCol{
+				{"at", "TIMESTAMPTZ", true, false}, {"today", "DATE", true, false}, {"ts", "TIMESTAMPTZ", true, false},
+				{"n", "BIGINT", false, false}, {"last_at", "TIMESTAMPTZ", true, false}, {"amount", "NUMERIC(12,2)", false, false},
+			},
+		},
+		{
+			name: "type hints",
+			sql: "-- @type total NUMERIC(12,2) NOT NULL\n-- @type user_id UUID NULL\n" +
+				"CREATE MATERIALIZED VIEW v AS SELECT o.user_id, sum(o.total) AS total FROM orders o GROUP BY o.user_id WITH NO DATA",
+			want: []viewCol{{"user_id", "UUID", true, false}, {"total", "NUMERIC(12,2)", false, false}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl := mustTable(t, viewTables+tt.sql+";", types.DialectPostgres, "", "v")
+			if !tbl.IsView {
+				t.Error("IsView = false")
+			}
+			var got []viewCol
+			for _, col := range tbl.Columns {
+				got = append(got, viewCol{col.Name, col.Type, col.Nullable, col.PrimaryKey})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("columns =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestViewTypeHintModifiers(t *testing.T) {
+	tbl := mustTable(t, viewTables+"/* @type total NUMERIC(14,4) */\nCREATE VIEW v AS SELECT sum(total) AS total FROM orders;",
+		types.DialectPostgres, "", "v")
+	total := mustColumn(t, tbl, "total")
+	if total.Precision != 14 || total.Scale != 4 || !total.Nullable {
+		t.Errorf("total = %+v, want a nullable NUMERIC(14,4)", total)
+	}
+}
+
+func TestDropView(t *testing.T) {
+	cat, err := parseSQL(t, viewTables+`
+CREATE VIEW a AS SELECT id FROM users;
+CREATE VIEW b AS SELECT id FROM orders;
+DROP VIEW IF EXISTS a, b CASCADE;
+DROP VIEW users;`, types.DialectPostgres)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if cat.FindTable("", "a") != nil || cat.FindTable("", "b") != nil {
+		t.Error("dropped views are still in the catalog")
+	}
+	if cat.FindTable("", "users") == nil {
+		t.Error("DROP VIEW removed the users table")
+	}
+}
+
+func TestSplitSelectAlias(t *testing.T) {
+	tests := []struct {
+		item  string
+		expr  string
+		alias string
+	}{
+		{"u.email", "u.email", ""},
+		{"u.email AS login", "u.email", "login"},
+		{`u.email AS "Login"`, "u.email", "Login"},
+		{"u.email login", "u.email", "login"},
+		{"count(*) n", "count(*)", "n"},
+		{"'x' label", "'x'", "label"},
+		{"CAST(total AS text)", "CAST(total AS text)", ""},
+		{"total::text", "total::text", ""},
+		{"a IS NOT NULL", "a IS NOT NULL", ""},
+		{"CASE WHEN a THEN 1 ELSE 2 END", "CASE WHEN a THEN 1 ELSE 2 END", ""},
+		{"CASE WHEN a THEN 1 ELSE 2 END kind", "CASE WHEN a THEN 1 ELSE 2 END", "kind"},
+		{"NULL note", "NULL", "note"},
+	}
+
+	for _, tt := range tests {
+		toks := mustLex(t, tt.item)
+		expr, alias := splitSelectAlias(toks)
+		got := ""
+		if len(expr) > 0 {
+			got = tt.item[expr[0].start:expr[len(expr)-1].end]
+		}
+		if got != tt.expr || alias != tt.alias {
+			t.Errorf("splitSelectAlias(%q) = %q, %q, want %q, %q", tt.item, got, alias, tt.expr, tt.alias)
+		}
+	}
+}
+
+func TestParseFromClause(t *testing.T) {
+	cat, err := parseSQL(t, viewTables, types.DialectPostgres)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	type source struct {
+		Alias    string
+		Table    string
+		Nullable bool
+	}
+	tests := []struct {
+		from string
+		want []source
+	}{
+		{"users", []source{{"users", "users", false}}},
+		{"users AS u, orders o", []source{{"u", "users", false}, {"o", "orders", false}}},
+		{"public.users u WHERE u.id IS NOT NULL", []source{{"u", "users", false}}},
+		{"users u LEFT JOIN orders o ON o.user_id = u.id", []source{{"u", "users", false}, {"o", "orders", true}}},
+		{"users u RIGHT JOIN orders o USING (id)", []source{{"u", "users", true}, {"o", "orders", false}}},
+		{"users u FULL OUTER JOIN orders o ON true", []source{{"u", "users", true}, {"o", "orders", true}}},
+		{"orders o LEFT JOIN (users u JOIN orders x ON x.user_id = u.id) ON u.id = o.user_id",
+			[]source{{"o", "orders", false}, {"u", "users", true}, {"x", "orders", true}}},
+		{"(SELECT 1) s CROSS JOIN missing m", []source{{"s", "", false}, {"m", "", false}}},
+	}
+
+	for _, tt := range tests {
+		var got []source
+		p := newDDLParser(cat, "test.sql", statement{src: tt.from}, types.DialectPostgres)
+		for _, src := range p.fromClause("public", mustLex(t, tt.from)) {
+			s := source{Alias: src.alias, Nullable: src.nullable}
+			if src.table != nil {
+				s.Table = src.table.Name
+			}
+			got = append(got, s)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseFromClause(%q) = %+v, want %+v", tt.from, got, tt.want)
+		}
+	}
+}
+
+// mustLex returns the PostgreSQL tokens of sql
+func mustLex(t *testing.T, sql string) []token {
+	t.Helper()
+	toks, _, err := lex(sql, types.DialectPostgres)
+	if err != nil {
+		t.Fatalf("lex(%q) error = %v", sql, err)
+	}
+	return toks
+}
@@ -6,6 +6,7 @@ type BuilderConfig struct {
 	Version       string
 	Tables        []TableConfig
 	RoutePrefix   string
+	ModulePath    string // import path of the project module, e.g. github.com/acme/billing
 	ImportPath    string
 	HandlerPrefix string
 	HasAuth       bool
@@ -18,4 +19,6 @@ type TableConfig struct {
 	Name        string
 	DisplayName string
 	Module      string
+	IsView      bool   // views only get finder wiring
+	KeyPath     string // route parameters of the primary key, e.g. "/:id"
 }
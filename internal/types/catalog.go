@@ -0,0 +1,118 @@
+package types
+
+import "strings"
+
+// Catalog holds every table known after replaying a schema source
+type Catalog struct {
+	Tables   []*Table     `json:"tables" yaml:"tables"`
+	Enums    []*Enum      `json:"enums,omitempty" yaml:"enums,omitempty"`
+	Warnings []Diagnostic `json:"warnings,omitempty" yaml:"warnings,omitempty"` // clauses the parser ignored while replaying the source
+}
+
+// FindTable returns the table matching schema and name, or nil if none exists.
+// Tables declared without a schema match any requested schema.
+func (c *Catalog) FindTable(schema, name string) *Table {
+	name = strings.ToLower(name)
+	schema = strings.ToLower(schema)
+
+	var unqualified *Table
+	for _, t := range c.Tables {
+		if strings.ToLower(t.Name) != name {
+			continue
+		}
+		if strings.ToLower(t.Schema) == schema {
+			return t
+		}
+		if t.Schema == "" && unqualified == nil {
+			unqualified = t
+		}
+	}
+	if unqualified != nil {
+		return unqualified
+	}
+
+	// An unqualified reference resolves to the only table with that name
+	if schema == "" {
+		var match *Table
+		for _, t := range c.Tables {
+			if strings.ToLower(t.Name) == name {
+				if match != nil {
+					return nil
+				}
+				match = t
+			}
+		}
+		return match
+	}
+	return nil
+}
+
+// AddTable registers a table, replacing any previous table with the same name
+func (c *Catalog) AddTable(t *Table) {
+	for i, existing := range c.Tables {
+		if strings.EqualFold(existing.Schema, t.Schema) && strings.EqualFold(existing.Name, t.Name) {
+			c.Tables[i] = t
+			return
+		}
+	}
+	c.Tables = append(c.Tables, t)
+}
+
+// RemoveTable drops a table from the catalog
+func (c *Catalog) RemoveTable(t *Table) {
+	for i, existing := range c.Tables {
+		if existing == t {
+			c.Tables = append(c.Tables[:i], c.Tables[i+1:]...)
+			return
+		}
+	}
+}
+
+// FindEnum returns the enum matching schema and name, or nil if none exists.
+// Enums declared without a schema match any requested schema.
+func (c *Catalog) FindEnum(schema, name string) *Enum {
+	var unqualified *Enum
+	for _, e := range c.Enums {
+		if !strings.EqualFold(e.Name, name) {
+			continue
+		}
+		if strings.EqualFold(e.Schema, schema) {
+			return e
+		}
+		if unqualified == nil && (e.Schema == "" || schema == "") {
+			unqualified = e
+		}
+	}
+	return unqualified
+}
+
+// AddEnum registers an enum, replacing any previous enum with the same name
+func (c *Catalog) AddEnum(e *Enum) {
+	for i, existing := range c.Enums {
+		if strings.EqualFold(existing.Schema, e.Schema) && strings.EqualFold(existing.Name, e.Name) {
+			c.Enums[i] = e
+			return
+		}
+	}
+	c.Enums = append(c.Enums, e)
+}
+
+// RemoveEnum drops an enum from the catalog
+func (c *Catalog) RemoveEnum(e *Enum) {
+	for i, existing := range c.Enums {
+		if existing == e {
+			c.Enums = append(c.Enums[:i], c.Enums[i+1:]...)
+			return
+		}
+	}
+}
+
+// FindColumn returns a pointer to the named column, or nil if it does not exist
+func (t *Table) FindColumn(name string) *Column {
+	for i := range t.Columns {
+		if strings.EqualFold(t.Columns[i].Name, name) {
+			return &t.Columns[i]
+		}
+	}
+	return nil
+}
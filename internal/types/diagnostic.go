@@ -0,0 +1,28 @@
+package types
+
+import "fmt"
+
+// Diagnostic is a positioned message about a schema source, such as a syntax
+// error or a clause the parser ignored
+type Diagnostic struct {
+	File    string `json:"file,omitempty" yaml:"file,omitempty"`
+	Line    int    `json:"line,omitempty" yaml:"line,omitempty"` // 1-based
+	Col     int    `json:"col,omitempty" yaml:"col,omitempty"`   // 1-based, in characters (runes)
+	Message string `json:"message" yaml:"message"`
+}
+
+// String formats the diagnostic as "file:line:col: message"
+func (d Diagnostic) String() string {
+	switch {
+	case d.File == "":
+		return fmt.Sprintf("%d:%d: %s", d.Line, d.Col, d.Message)
+	case d.Line == 0:
+		return fmt.Sprintf("%s: %s", d.File, d.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", d.File, d.Line, d.Col, d.Message)
+}
+
+// Error lets a diagnostic be returned as a parse error
+func (d *Diagnostic) Error() string {
+	return d.String()
+}
@@ -0,0 +1,40 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dialect identifies the SQL dialect a schema source is written in
+type Dialect string
+
+const (
+	// DialectPostgres is PostgreSQL, the default dialect
+	DialectPostgres Dialect = "postgres"
+	// DialectMySQL is MySQL and MariaDB
+	DialectMySQL Dialect = "mysql"
+	// DialectSQLite is SQLite
+	DialectSQLite Dialect = "sqlite"
+)
+
+// QuoteIdent quotes an identifier for use in SQL, with backticks for MySQL and
+// double quotes otherwise, so keywords such as order or user are read as names
+func (d Dialect) QuoteIdent(name string) string {
+	if d == DialectMySQL {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// ParseDialect resolves a dialect name or alias. An empty name selects PostgreSQL.
+func ParseDialect(name string) (Dialect, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "postgres", "postgresql", "pg":
+		return DialectPostgres, nil
+	case "mysql", "mariadb":
+		return DialectMySQL, nil
+	case "sqlite", "sqlite3":
+		return DialectSQLite, nil
+	}
+	return "", fmt.Errorf("unknown SQL dialect %q (supported: postgres, mysql, sqlite)", name)
+}
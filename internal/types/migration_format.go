@@ -0,0 +1,37 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MigrationFormat identifies the migration tool whose file layout a migrations
+// directory follows
+type MigrationFormat string
+
+const (
+	// FormatGolangMigrate is golang-migrate: <version>_<name>.up.sql files, the default
+	FormatGolangMigrate MigrationFormat = "golang-migrate"
+	// FormatGoose is goose: <version>_<name>.sql files with -- +goose Up/Down sections
+	FormatGoose MigrationFormat = "goose"
+	// FormatDbmate is dbmate: <version>_<name>.sql files with -- migrate:up/down sections
+	FormatDbmate MigrationFormat = "dbmate"
+	// FormatAtlas is an atlas versioned directory: <version>_<name>.sql files holding only up SQL
+	FormatAtlas MigrationFormat = "atlas"
+)
+
+// ParseMigrationFormat resolves a migration format name or alias.
+// An empty name selects golang-migrate.
+func ParseMigrationFormat(name string) (MigrationFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "golang-migrate", "migrate":
+		return FormatGolangMigrate, nil
+	case "goose":
+		return FormatGoose, nil
+	case "dbmate":
+		return FormatDbmate, nil
+	case "atlas":
+		return FormatAtlas, nil
+	}
+	return "", fmt.Errorf("unknown migration format %q (supported: golang-migrate, goose, dbmate, atlas)", name)
+}
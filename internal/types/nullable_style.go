@@ -0,0 +1,31 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NullableStyle selects the Go types of nullable columns
+type NullableStyle string
+
+const (
+	// NullableSQLNull uses the database/sql wrappers such as sql.NullString, the default style
+	NullableSQLNull NullableStyle = "sqlnull"
+	// NullablePointer uses pointers such as *string
+	NullablePointer NullableStyle = "pointer"
+	// NullableGeneric uses the generic sql.Null[T] of Go 1.22
+	NullableGeneric NullableStyle = "generic"
+)
+
+// ParseNullableStyle resolves a nullable style name. An empty name selects sqlnull.
+func ParseNullableStyle(name string) (NullableStyle, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "sqlnull", "sql":
+		return NullableSQLNull, nil
+	case "pointer", "ptr":
+		return NullablePointer, nil
+	case "generic":
+		return NullableGeneric, nil
+	}
+	return "", fmt.Errorf("unknown nullable style %q (supported: sqlnull, pointer, generic)", name)
+}
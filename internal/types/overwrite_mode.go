@@ -0,0 +1,31 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OverwriteMode selects what happens to generated files that already exist
+type OverwriteMode string
+
+const (
+	// OverwriteRefuse writes nothing and lists the existing files, the default mode
+	OverwriteRefuse OverwriteMode = "refuse"
+	// OverwriteForce replaces existing files
+	OverwriteForce OverwriteMode = "force"
+	// OverwriteSkip keeps existing files and only creates the missing ones
+	OverwriteSkip OverwriteMode = "skip"
+)
+
+// ParseOverwriteMode resolves an overwrite mode name. An empty name selects refuse.
+func ParseOverwriteMode(name string) (OverwriteMode, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "refuse":
+		return OverwriteRefuse, nil
+	case "force", "overwrite":
+		return OverwriteForce, nil
+	case "skip", "skip-existing", "skip_existing":
+		return OverwriteSkip, nil
+	}
+	return "", fmt.Errorf("unknown overwrite mode %q (supported: refuse, force, skip)", name)
+}
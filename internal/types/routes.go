@@ -6,9 +6,11 @@ type RoutesConfig struct {
 	Version       string
 	Tables        []TableConfig
 	RoutePrefix   string
+	ModulePath    string // import path of the project module, e.g. github.com/acme/billing
 	ImportPath    string
 	HandlerPrefix string
 	HandlerStruct string
+	HasWritable   bool // at least one table is not a view
 }
 
 // HandlerMethodConfig holds configuration for route methods
@@ -2,20 +2,73 @@ package types
 
 // Column metadata
 type Column struct {
-	Name       string
-	Type       string
-	Nullable   bool
-	PrimaryKey bool
+	Name       string `json:"name" yaml:"name"`
+	Type       string `json:"type" yaml:"type"`
+	Nullable   bool   `json:"nullable" yaml:"nullable"`
+	PrimaryKey bool   `json:"primary_key" yaml:"primary_key"`
+	Default    string `json:"default,omitempty" yaml:"default,omitempty"` // default expression, "" when the column has none
+	Unique     bool   `json:"unique,omitempty" yaml:"unique,omitempty"`
+	Check      string `json:"check,omitempty" yaml:"check,omitempty"`         // CHECK expression without the surrounding parentheses
+	Length     int    `json:"length,omitempty" yaml:"length,omitempty"`       // character length, e.g. 255 for VARCHAR(255)
+	Precision  int    `json:"precision,omitempty" yaml:"precision,omitempty"` // numeric precision, e.g. 12 for NUMERIC(12,2)
+	Scale      int    `json:"scale,omitempty" yaml:"scale,omitempty"`         // numeric scale, e.g. 2 for NUMERIC(12,2)
+	Generated  bool   `json:"generated,omitempty" yaml:"generated,omitempty"` // GENERATED ALWAYS AS (...) STORED
+	Identity   bool   `json:"identity,omitempty" yaml:"identity,omitempty"`   // GENERATED ... AS IDENTITY, a SERIAL type, AUTO_INCREMENT or a SQLite rowid alias
+	Unsigned   bool   `json:"unsigned,omitempty" yaml:"unsigned,omitempty"`   // MySQL UNSIGNED integer
+	Enum       *Enum  `json:"enum,omitempty" yaml:"enum,omitempty"`           // enum type of the column, nil for other types
+	Comment    string `json:"comment,omitempty" yaml:"comment,omitempty"`     // COMMENT ON COLUMN text
+}
+
+// Enum metadata for CREATE TYPE ... AS ENUM or an inline MySQL ENUM(...) column
+type Enum struct {
+	Schema string   `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Name   string   `json:"name" yaml:"name"`
+	Values []string `json:"values" yaml:"values"`
+}
+
+// Index metadata for a UNIQUE constraint or an index on plain columns
+type Index struct {
+	Name    string   `json:"name,omitempty" yaml:"name,omitempty"`
+	Columns []string `json:"columns" yaml:"columns"`
+	Unique  bool     `json:"unique" yaml:"unique"`
+}
+
+// ForeignKey metadata
+type ForeignKey struct {
+	Name       string   `json:"name,omitempty" yaml:"name,omitempty"`
+	Columns    []string `json:"columns" yaml:"columns"`
+	RefSchema  string   `json:"ref_schema,omitempty" yaml:"ref_schema,omitempty"`
+	RefTable   string   `json:"ref_table" yaml:"ref_table"`
+	RefColumns []string `json:"ref_columns" yaml:"ref_columns"`
+	OnDelete   string   `json:"on_delete,omitempty" yaml:"on_delete,omitempty"` // "CASCADE", "SET NULL", ... or "" when not specified
+	OnUpdate   string   `json:"on_update,omitempty" yaml:"on_update,omitempty"`
+}
+
+// Relation describes a GORM association derived from a foreign key
+type Relation struct {
+	Kind       string `json:"kind" yaml:"kind"`                                   // "belongs_to" or "has_many"
+	FieldName  string `json:"field_name,omitempty" yaml:"field_name,omitempty"`   // Go field name of the association
+	Entity     string `json:"entity,omitempty" yaml:"entity,omitempty"`           // Go type name of the associated entity
+	ForeignKey string `json:"foreign_key,omitempty" yaml:"foreign_key,omitempty"` // Go field name(s) holding the foreign key
+	References string `json:"references,omitempty" yaml:"references,omitempty"`   // Go field name(s) referenced by the foreign key
+	Constraint string `json:"constraint,omitempty" yaml:"constraint,omitempty"`   // GORM constraint options, e.g. "OnDelete:CASCADE"
+	JSONName   string `json:"json_name,omitempty" yaml:"json_name,omitempty"`
 }
 
 // Table metadata
 type Table struct {
-	Schema    string
-	Name      string
-	NameUpper string
-	NameLower string
-	Columns   []Column
-	IsView    bool
+	Schema       string       `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Name         string       `json:"name" yaml:"name"`
+	NameUpper    string       `json:"name_upper,omitempty" yaml:"name_upper,omitempty"`
+	NameLower    string       `json:"name_lower,omitempty" yaml:"name_lower,omitempty"`
+	Columns      []Column     `json:"columns" yaml:"columns"`
+	ForeignKeys  []ForeignKey `json:"foreign_keys,omitempty" yaml:"foreign_keys,omitempty"`
+	Indexes      []Index      `json:"indexes,omitempty" yaml:"indexes,omitempty"`
+	Relations    []Relation   `json:"relations,omitempty" yaml:"relations,omitempty"`
+	IsView       bool         `json:"is_view,omitempty" yaml:"is_view,omitempty"`
+	WithoutRowID bool         `json:"without_rowid,omitempty" yaml:"without_rowid,omitempty"` // SQLite WITHOUT ROWID table
+	Comment      string       `json:"comment,omitempty" yaml:"comment,omitempty"`             // COMMENT ON TABLE text
+	Source       string       `json:"source,omitempty" yaml:"source,omitempty"`               // file that created the table
 }
 
 // ModulePart defines which module components to generate